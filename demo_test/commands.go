@@ -0,0 +1,536 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	manager "devicemanager/demo_test/proto"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//commandSpec describes the ':' separated field layout a command expects for each of its targets and its usage text,
+//a passthrough command only checks its first argument and handles the rest of the line itself and an exact command
+//has to be typed in full
+type commandSpec struct {
+	name        string
+	layout      string
+	parts       []int
+	multiple    bool
+	passthrough bool
+	exact       bool
+	help        string
+}
+
+//commandSpecs holds the argument layout of every command; commands without parts take no arguments
+var commandSpecs = []commandSpec{
+	{name: "attach", layout: "ip:port:period:detect:passauth[:skipverify]", parts: []int{5, 6}, multiple: true, help: "attach - attach a device and detect Device, the device certificate is verified unless skip verify is true\n\tUsage: ./dm attach <ip address:port:period:detect Device:Do not authenticate[:skip certificate verify]>"},
+	{name: "detach", layout: "ip:port:token", parts: []int{3}, multiple: true, exact: true, help: "detach - detach a device\n\tUsage: ./dm detach <ip address:port:token>\ndetach - detach every attached device whose ip address is in a subnet or matches a glob\n\tUsage: ./dm detach <CIDR or glob:token>"},
+	{name: "decommission", layout: "ip:port:token", parts: []int{3}, multiple: true, exact: true, help: "decommission - stop polling device, delete the syslog subscriptions set by setsyslog and detach it, each step is reported and the device stays attached when unsubscribing fails\n\tUsage: ./dm decommission <ip address:port:token>"},
+	{name: "period", layout: "ip:port:token:value", parts: []int{4}, help: "period - a period of quering device data\n\tUsage: ./dm period <ip address:port:token:period>"},
+	{name: "periodall", layout: "token:value", parts: []int{2}, help: "periodall - set the period of quering device data of every attached device and report how many succeeded\n\tUsage: ./dm periodall <token:period>"},
+	{name: "setpolltimeout", layout: "ip:port:token:seconds", parts: []int{4}, multiple: true, help: "setpolltimeout - set the time a Redfish GET of device may take before it fails, 0 restores the global poll timeout\n\tUsage: ./dm setpolltimeout <ip address:port:token:seconds>"},
+	{name: "showdevices", help: "showdevices - show registered device, a device failing repeated polling cycles is marked (DEGRADED)\n\tUsage: ./dm showdevices <none>"},
+	{name: "creategroup", layout: "group", parts: []int{1}, multiple: true, help: "creategroup - create a device group, its members can be targeted as @group in place of ip address:port\n\tUsage: ./dm creategroup <group name>"},
+	{name: "addtogroup", layout: "group:ip:port", parts: []int{3}, multiple: true, help: "addtogroup - add a registered device to a group\n\tUsage: ./dm addtogroup <group name:ip address:port>"},
+	{name: "listgroups", help: "listgroups - show device groups and their members\n\tUsage: ./dm listgroups <none>"},
+	{name: "publishtestevent", layout: "topic:message", parts: []int{2}, passthrough: true, help: "publishtestevent - publish a synthetic device event carrying the message to a Kafka topic (the configured topic when empty), the event is marked \"Synthetic\": true\n\tUsage: ./dm publishtestevent <topic:message>"},
+	{name: "rungroup", layout: "group", parts: []int{1}, passthrough: true, help: "rungroup - run a command against every member of a group, the member ip address:port is prepended to each command argument and the results are listed per device\n\tUsage: ./dm rungroup <group name> <command> <command arguments without ip address:port>"},
+	{name: "createaccount", layout: "ip:port:token:username:password:privilege", parts: []int{6}, multiple: true, help: "createaccount - create an account\n\tUsage: ./dm createaccount <ip address:port:token:username:password:privilege>"},
+	{name: "createaccountsfromfile", layout: "path", parts: []int{1}, passthrough: true, help: "createaccountsfromfile - create the accounts of a CSV file of ip,port,token,username,password,privilege rows on the demotest host and report every row, a header row and lines starting with '#' are ignored and malformed rows are skipped\n\tUsage: ./dm createaccountsfromfile <CSV file path>"},
+	{name: "deleteaccount", layout: "ip:port:token:username", parts: []int{4}, multiple: true, exact: true, help: "deleteaccount - delete an account\n\tUsage: ./dm deleteaccount <ip address:port:token:username>"},
+	{name: "changeuserpassword", layout: "ip:port:token:username:password", parts: []int{5}, multiple: true, help: "changeuserpassword - change user password\n\tUsage: ./dm changeuserpassword <ip address:port:token:username:new passowrd>"},
+	{name: "storecreds", layout: "ip:port:username:password", parts: []int{4}, multiple: true, help: "storecreds - keep the username and password of device in the encrypted credential store (credstore and credstorekey of the config), logindevice uses them when they are omitted\n\tUsage: ./dm storecreds <ip address:port:username:password>"},
+	{name: "logindevice", layout: "ip:port[:username:password]:basicauth", parts: []int{3, 5}, multiple: true, help: "logindevice - login to device, the credentials stored by storecreds are used when username and password are omitted\n\tUsage: ./dm logindevice <ip address:port[:username:password]:<false:Token/true:Basic Authentication>>"},
+	{name: "logoutdevice", layout: "ip:port:token:username", parts: []int{4}, multiple: true, help: "logoutdevice - logout the device\n\tUsage: ./dm logoutdevice <ip address:port:token:username>"},
+	{name: "startquerydevice", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "startquerydevice - start to query device\n\tUsage: ./dm startquerydevice <ip address:port:token>"},
+	{name: "stopquerydevice", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "stopquerydevice - stop to query device\n\tUsage: ./dm stopquerydevice <ip address:port:token>"},
+	{name: "deviceaccountslist", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "deviceaccountslist - show device accounts\n\tUsage: ./dm deviceaccountslist <ip address:port:token>"},
+	{name: "setsessionservice", layout: "ip:port:token:enabled:timeout", parts: []int{5}, multiple: true, help: "setsessionservice - configure device authoriation\n\tUsage: ./dm setsessionservice <ip address:port:token:<true or false>:session timeout>"},
+	{name: "getsessionservice", layout: "ip:port:token", parts: []int{3}, help: "getsessionservice - show device session service enabled state and session timeout\n\tUsage: ./dm getsessionservice <ip address:port:token>"},
+	{name: "getaccountpolicy", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getaccountpolicy - show the password length and account lockout policies of the device AccountService, a property the device does not report is listed as not reported\n\tUsage: ./dm getaccountpolicy <ip address:port:token>"},
+	{name: "setaccountpolicy", layout: "ip:port:token:threshold:duration:minpasswordlength", parts: []int{6}, multiple: true, help: "setaccountpolicy - set the account lockout threshold, lockout duration (seconds) and minimum password length of the device AccountService and show the applied policy, an empty value is left unchanged\n\tUsage: ./dm setaccountpolicy <ip address:port:token:lockout threshold:lockout duration:min password length>"},
+	{name: "addpollingrfapi", layout: "ip:port:token:rfapi", parts: []int{4}, multiple: true, help: "addpollingrfapi - add Redfish API to poll device data periodically\n\tUsage: ./dm addpollingrfapi <ip address:port:token:Redfish API>"},
+	{name: "removepollingrfapi", layout: "ip:port:token:rfapi", parts: []int{4}, multiple: true, help: "removepollingrfapi - remove Redfish API from polling device data periodically\n\tUsage: ./dm removepollingrfapi <ip address:port:token:Redfish API>"},
+	{name: "clearpollingrfapi", layout: "ip:port:token", parts: []int{3}, multiple: true, exact: true, help: "clearpollingrfapi - clear Redfish API from polling device data periodically\n\tUsage: ./dm clearpollingrfapi <ip address:port:token>"},
+	{name: "getpollingrflist", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getpollingrflist - show added Redfish API to poll device data periodically\n\tUsage: ./dm getpollingrflist <ip address:port:token>"},
+	{name: "setpollinglist", layout: "ip:port:token:rfapis", parts: []int{4}, multiple: true, help: "setpollinglist - replace the Redfish API list polling device data periodically with the comma separated Redfish APIs and show the resulting list, nothing changes when one of them is invalid and an empty list clears it\n\tUsage: ./dm setpollinglist <ip address:port:token:Redfish API,Redfish API,...>"},
+	{name: "pollnow", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "pollnow - poll every polling Redfish API of device at once and refresh the cached device data, returns when the poll is complete\n\tUsage: ./dm pollnow <ip address:port:token>"},
+	{name: "findpollingrfapi", layout: "token:rfapi", parts: []int{2}, help: "findpollingrfapi - list the attached devices whose polling Redfish API list holds the Redfish API and the devices missing it\n\tUsage: ./dm findpollingrfapi <token:Redfish API>"},
+	{name: "finddupes", help: "finddupes - report the attached devices registered more than once under different ip address:port forms (e.g. leading zeros), grouped by the normalized address\n\tUsage: ./dm finddupes <none>"},
+	{name: "setlogservice", layout: "ip:port:token:log_id:enabled", parts: []int{5}, multiple: true, help: "setlogservice - enable/disable log service to device\n\tUsage: ./dm setlogservice <ip address:port:token:log_id:<true or false>>"},
+	{name: "resetlogdata", layout: "ip:port:token:log_id", parts: []int{4}, multiple: true, exact: true, help: "resetlogdata - reset all log data to device\n\tUsage: ./dm resetlogdata <ip address:port:token:log_id>"},
+	{name: "getdevicelogdata", layout: "ip:port:token[:log_id]", parts: []int{3, 4}, help: "getdevicelogdata - get all log data to device (maximum data count: 1000), the first log service is used when log_id is omitted, \"manager\" or \"system\" selects the BMC or host log (e.g. \"manager/EventLog\", \"system/SEL\")\n\tUsage: ./dm getdevicelogdata <ip address:port:token[:log_id]>"},
+	{name: "pulldevicelogdata", layout: "ip:port:token[:log_id]", parts: []int{3, 4}, help: "pulldevicelogdata - stream every log entry of device in batches, one entry per line, printing or appending each batch to the output file as it arrives, the first log service is used when log_id is omitted\n\tUsage: ./dm pulldevicelogdata <ip address:port:token[:log_id]>"},
+	{name: "streamdevicelogdata", layout: "ip:port:token:interval[:log_id]", parts: []int{4, 5}, help: "streamdevicelogdata - print new log entries of device every interval (seconds) until interrupted, the first log service is used when log_id is omitted\n\tUsage: ./dm streamdevicelogdata <ip address:port:token:interval[:log_id]>"},
+	{name: "listlogservices", layout: "ip:port:token", parts: []int{3}, help: "listlogservices - list the log services of device\n\tUsage: ./dm listlogservices <ip address:port:token>"},
+	{name: "geteventservice", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "geteventservice - show whether the Redfish event service of device is enabled, event subscriptions need it\n\tUsage: ./dm geteventservice <ip address:port:token>"},
+	{name: "seteventservice", layout: "ip:port:token:enabled", parts: []int{4}, multiple: true, help: "seteventservice - enable (true) or disable (false) the Redfish event service of device\n\tUsage: ./dm seteventservice <ip address:port:token:true or false>"},
+	{name: "getserialconsole", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getserialconsole - show whether the serial (SOL) and graphical consoles of device are enabled with their connect types and ports\n\tUsage: ./dm getserialconsole <ip address:port:token>"},
+	{name: "setserialconsole", layout: "ip:port:token:console:enabled", parts: []int{5}, multiple: true, help: "setserialconsole - enable (true) or disable (false) the serial or graphical console of device\n\tUsage: ./dm setserialconsole <ip address:port:token:serial or graphical:true or false>"},
+	{name: "setipmilan", layout: "ip:port:token:enabled", parts: []int{4}, multiple: true, help: "setipmilan - enable (true) or disable (false) IPMI-over-LAN of device, through the standard IPMI protocol setting or an OEM extension\n\tUsage: ./dm setipmilan <ip address:port:token:true or false>"},
+	{name: "maintenance", layout: "ip:port:token:start:end", parts: []int{5}, multiple: true, help: "maintenance - tag the Kafka messages of device with the \"maintenance: true\" header from start to end, each is \"now\", Unix seconds or a duration counted from now for start and from start for end, 0:0 clears the window\n\tUsage: ./dm maintenance <ip address:port:token:start:end>"},
+	{name: "setsyslog", layout: "ip:port:token:host:port", parts: []int{5}, help: "setsyslog - forward device logs to a syslog collector\n\tUsage: ./dm setsyslog <ip address:port:token:syslog host:syslog port>"},
+	{name: "getdeviceresettype", layout: "ip:port:token", parts: []int{3}, help: "getdeviceresettype - get device reset system type\n\tUsage: ./dm getdeviceresettype <ip address:port:token>"},
+	{name: "resetdevicesystem", layout: "ip:port:token:resettype", parts: []int{4}, exact: true, help: "resetdevicesystem - reset device system (supported reset type is \"GracefulRestart\". BMC supports \"ForceOn\", \"ForceOff\" and \"ForceReset\")\n\tUsage: ./dm resetdevicesystem <ip address:port:token:Reset type>"},
+	{name: "resetbmc", layout: "ip:port:token:resettype:confirm", parts: []int{5}, exact: true, help: "resetbmc - reset BMC configuration to factory defaults (reset type e.g. \"ResetAll\", \"PreserveNetwork\"), the last argument must be \"yes\"\n\tUsage: ./dm resetbmc <ip address:port:token:Reset type:yes>"},
+	{name: "getdatetime", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getdatetime - show the BMC DateTime, DateTimeLocalOffset and the skew from the local clock\n\tUsage: ./dm getdatetime <ip address:port:token>"},
+	{name: "setdatetime", layout: "ip:port:token:time[:offset]", parts: []int{4, 5}, multiple: true, help: "setdatetime - set the BMC DateTime to \"now\" (local clock) or to Unix seconds, optionally with a local offset such as +0800\n\tUsage: ./dm setdatetime <ip address:port:token:<now or Unix seconds>[:local offset]>"},
+	{name: "getbmccert", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getbmccert - show subject, issuer and expiry of the BMC HTTPS certificate\n\tUsage: ./dm getbmccert <ip address:port:token>"},
+	{name: "setbmccert", layout: "ip:port:token:certpath:keypath", parts: []int{5}, multiple: true, help: "setbmccert - replace the BMC HTTPS certificate with local PEM certificate and private key files\n\tUsage: ./dm setbmccert <ip address:port:token:certificate file path:private key file path>"},
+	{name: "gethealth", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "gethealth - show the health rollup (OK, Warning or Critical) of device systems, chassis and managers\n\tUsage: ./dm gethealth <ip address:port:token>"},
+	{name: "fleetget", layout: "token:rfapi", parts: []int{2}, help: "fleetget - read the Redfish API from every attached device and show a JSON object mapping each device to the resource or to its error\n\tUsage: ./dm fleetget <token:Redfish API>"},
+	{name: "pollcheck", layout: "token[:seconds]", parts: []int{1, 2}, help: "pollcheck - list the attached devices whose last successful poll is older than the freshness threshold (default 300 seconds) or that were never polled successfully\n\tUsage: ./dm pollcheck <token[:threshold seconds]>"},
+	{name: "healthall", layout: "token", parts: []int{1}, help: "healthall - count attached devices by health rollup (OK, Warning, Critical) and list the devices that are not OK\n\tUsage: ./dm healthall <token>"},
+	{name: "getportstats", layout: "ip:port:token", parts: []int{3}, help: "getportstats - show RX/TX byte and error counters of every network adapter port of device\n\tUsage: ./dm getportstats <ip address:port:token>"},
+	{name: "getfanredundancy", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getfanredundancy - show the fan/thermal redundancy groups (e.g. N+1) of device, REDUNDANCY_LOST is flagged when a group is degraded\n\tUsage: ./dm getfanredundancy <ip address:port:token>"},
+	{name: "getpowerredundancy", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getpowerredundancy - show the power redundancy groups of device and the input of each power supply, REDUNDANCY_LOST is flagged when a group is degraded\n\tUsage: ./dm getpowerredundancy <ip address:port:token>"},
+	{name: "getbootprogress", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getbootprogress - show the power state and the last boot progress state (e.g. PCIResourceConfigStarted, OSRunning) of device\n\tUsage: ./dm getbootprogress <ip address:port:token>"},
+	{name: "getpciedevices", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getpciedevices - show name, type, class and firmware version of the PCIe devices (e.g. GPUs, NICs) of device\n\tUsage: ./dm getpciedevices <ip address:port:token>"},
+	{name: "getgputelemetry", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getgputelemetry - show temperature, power and utilization of every GPU or other accelerator of device, read from the processor metrics or OEM metrics, a host without accelerators shows none\n\tUsage: ./dm getgputelemetry <ip address:port:token>"},
+	{name: "checkconformance", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "checkconformance - probe the standard Redfish resources (ServiceRoot, Systems, Chassis, Managers, EventService) of device and report which are PRESENT, ABSENT or MALFORMED\n\tUsage: ./dm checkconformance <ip address:port:token>"},
+	{name: "getmfginfo", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getmfginfo - show manufacturer, model, serial number, part number, SKU, manufacture date and OEM warranty fields of device, fields the device lacks are omitted\n\tUsage: ./dm getmfginfo <ip address:port:token>"},
+	{name: "getlocation", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getlocation - show the physical location (slot, row, rack and rack position) of every chassis of device and the enclosure containing it\n\tUsage: ./dm getlocation <ip address:port:token>"},
+	{name: "getwatchdog", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getwatchdog - show whether the host watchdog timer of device is armed, its timeout action with the allowable values and its timeout when the device exposes it\n\tUsage: ./dm getwatchdog <ip address:port:token>"},
+	{name: "setwatchdog", layout: "ip:port:token:enabled[:action[:timeout]]", parts: []int{4, 5, 6}, multiple: true, help: "setwatchdog - arm (true) or disarm (false) the host watchdog timer of device, optionally set the timeout action (e.g. \"ResetSystem\") and the timeout in seconds, an empty value is left unchanged\n\tUsage: ./dm setwatchdog <ip address:port:token:true or false[:timeout action[:timeout seconds]]>"},
+	{name: "getfancontrol", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getfancontrol - show the OEM fan control mode with its allowable values, the PWM duty, whether manual fan control is supported and the reading of every fan of device\n\tUsage: ./dm getfancontrol <ip address:port:token>"},
+	{name: "setfancontrol", layout: "ip:port:token:mode[:pwm]", parts: []int{4, 5}, multiple: true, help: "setfancontrol - set the OEM fan control mode (e.g. \"Manual\") and optionally the PWM duty in percent (1-100) of device, an empty value is left unchanged\n\tUsage: ./dm setfancontrol <ip address:port:token:mode[:PWM percent]>"},
+	{name: "getbootpolicy", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getbootpolicy - show the automatic boot retry config and AC power restore policy of device with their allowable values\n\tUsage: ./dm getbootpolicy <ip address:port:token>"},
+	{name: "setbootpolicy", layout: "ip:port:token:retryconfig:restorepolicy", parts: []int{5}, multiple: true, help: "setbootpolicy - set the automatic boot retry config (e.g. \"RetryAttempts\") and AC power restore policy (e.g. \"AlwaysOn\"), an empty value is left unchanged\n\tUsage: ./dm setbootpolicy <ip address:port:token:automatic retry config:power restore policy>"},
+	{name: "clearbootoverride", layout: "ip:port:token", parts: []int{3}, multiple: true, exact: true, help: "clearbootoverride - set the boot source override of device back to Disabled so it boots from its boot order, the previous override is shown\n\tUsage: ./dm clearbootoverride <ip address:port:token>"},
+	{name: "getbootorder", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getbootorder - show the persistent boot order of device, each boot option reference with its display name\n\tUsage: ./dm getbootorder <ip address:port:token>"},
+	{name: "getcapacity", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getcapacity - show the total installed memory (GiB) and logical processor count of device summed over its systems\n\tUsage: ./dm getcapacity <ip address:port:token>"},
+	{name: "getallowablevalues", layout: "ip:port:token:action", parts: []int{4}, multiple: true, help: "getallowablevalues - show the allowable parameter values of a Redfish action (e.g. \"ComputerSystem.Reset\" or \"Reset\") of device, every resource offering the action is listed with its target\n\tUsage: ./dm getallowablevalues <ip address:port:token:action>"},
+	{name: "exportbios", layout: "ip:port:token:path", parts: []int{4}, help: "exportbios - write the Bios attributes of the first system of device to a new JSON file in the output directory of demotest\n\tUsage: ./dm exportbios <ip address:port:token:file path>"},
+	{name: "importbios", layout: "ip:port:token:path", parts: []int{4}, multiple: true, help: "importbios - stage the Bios attributes of a JSON file in the output directory written by exportbios on device, applied at the next reset, and report the attributes the device rejected\n\tUsage: ./dm importbios <ip address:port:token:file path>"},
+	{name: "getdevicetemperaturedata", layout: "ip:port:token", parts: []int{3}, help: "getdevicetemperaturedata - get device tempertures infomation\n\tUsage: ./dm getdevicetemperaturedata <ip address:port:token>"},
+	{name: "gettempthresholds", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "gettempthresholds - show the reading and the critical and fatal shutdown thresholds of every temperature sensor of device with the headroom left before shutdown\n\tUsage: ./dm gettempthresholds <ip address:port:token>"},
+	{name: "setdevicetemperaturedata", layout: "ip:port:token:member_id:upper:lower", parts: []int{6}, help: "setdevicetemperaturedata - configure the device event temperature\n\tUsage: ./dm setdevicetemperaturedata <ip address:port:token:member id:upperThresholdNonCritical:lowerThresholdNonCritical>"},
+	{name: "devicesoftwareupdate", layout: "ip:port:token:type:protocol:server:server_port:uri[:method[:applytime]]", parts: []int{8, 9, 10}, multiple: true, exact: true, help: "devicesoftwareupdate - start to update device and send Multiple Updater (MU) download site\n\tUsage: ./dm devicesoftwareupdate <ip address:port:token:MU:<http or https or tftp>:<server IP address:<port or \"\">:multiple updater download URI>\ndevicesoftwareupdate - start to update device and send Network OS (NOS) download site\n\tUsage: ./dm devicesoftwareupdate <ip address:port:token:NOS:<http or https or tftp>:<server IP address:<port or \"\">:Network OS file download URI>\ndevicesoftwareupdate - start to update device and send system install package download site\n\tUsage: ./dm devicesoftwareupdate <ip address:port:token:PACKAGE:<http or https or tftp>:<server IP address:<port or \"\">:system package file download URI>\ndevicesoftwareupdate - push a local software image file to the device UpdateService multipart endpoint (protocol, server and port are ignored)\n\tUsage: ./dm devicesoftwareupdate <ip address:port:token:type:\"\":\"\":\"\":local file path:push>\ndevicesoftwareupdate - stage the update with a Redfish apply time (Immediate, OnReset, AtMaintenanceWindowStart ...), method is pull or push\n\tUsage: ./dm devicesoftwareupdate <ip address:port:token:type:protocol:server IP address:port:URI or local file path:method:apply time>"},
+	{name: "getupdatestatus", layout: "ip:port:token[:protocol]", parts: []int{3, 4}, multiple: true, help: "getupdatestatus - pre-flight check of the device UpdateService: enabled state, SimpleUpdate transfer protocols, multipart push, apply times and the active update task, ready when enabled, idle and supporting the given protocol (e.g. HTTPS)\n\tUsage: ./dm getupdatestatus <ip address:port:token[:transfer protocol]>"},
+	{name: "cancelupdate", layout: "ip:port:token", parts: []int{3}, help: "cancelupdate - cancel the active software update task of device\n\tUsage: ./dm cancelupdate <ip address:port:token>"},
+	{name: "getfirmwareinventory", layout: "ip:port:token", parts: []int{3}, help: "getfirmwareinventory - show id, name and version of every firmware component of device\n\tUsage: ./dm getfirmwareinventory <ip address:port:token>"},
+	{name: "getfwbanks", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getfwbanks - show the active and backup firmware versions of the device manager and which bank is active, devices with a single firmware bank report no backup\n\tUsage: ./dm getfwbanks <ip address:port:token>"},
+	{name: "switchfwbank", layout: "ip:port:token", parts: []int{3}, multiple: true, exact: true, help: "switchfwbank - make the backup firmware bank of the device manager the active one, the manager boots from it on its next reset\n\tUsage: ./dm switchfwbank <ip address:port:token>"},
+	{name: "gethostname", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "gethostname - show the HostName and FQDN the device manager EthernetInterface reports\n\tUsage: ./dm gethostname <ip address:port:token>"},
+	{name: "sethostname", layout: "ip:port:token:hostname", parts: []int{4}, help: "sethostname - set the HostName of the device manager EthernetInterface (RFC 1123) and show the name the device applied\n\tUsage: ./dm sethostname <ip address:port:token:host name>"},
+	{name: "getdevicedata", layout: "ip:port:token:rfapi[:jsonpath]", parts: []int{4, 5}, help: "getdevicedata - get device data from cache, only the values matched by the JSON path (e.g. $.PowerControl[0].PowerConsumedWatts) are returned when it is given\n\tUsage: ./dm getdevicedata <ip address:port:token:Redfish API[:JSON path]>"},
+	{name: "diffdevices", layout: "ip1:port1:token1:ip2:port2:token2:rfapi", parts: []int{7}, help: "diffdevices - compare the same Redfish resource of two devices and show the keys that differ in value or presence\n\tUsage: ./dm diffdevices <ip address 1:port 1:token 1:ip address 2:port 2:token 2:Redfish API>"},
+	{name: "deviceaccess", layout: "ip:port:token:method:rfapi[:data]", parts: []int{5, 6}, help: "deviceaccess - access device data by Redfish API\n\tUsage: ./dm deviceaccess <ip address:port:token:HTTP method:Redfish API:HTTP DELETE/PATCH data>"},
+	{name: "sethttpcontenttype", layout: "ip:port:contenttype", parts: []int{3}, help: "sethttpcontenttype - set device HTTP Content Type\n\tUsage: ./dm sethttpcontenttype <ip address:port:http content type>"},
+	{name: "sethttptype", layout: "ip:port:httptype", parts: []int{3}, help: "sethttptype - set device HTTP Type (http or https)\n\tUsage: ./dm sethttptype <ip address:port:http or https>"},
+	{name: "simpleupdate", layout: "ip:port:token:protocol:imageuri[:targets:transferprotocol:username:password]", parts: []int{5, 9}, exact: true, help: "simpleupdate - send Simple Update\n\tUsage: ./dm simpleupdate <ip address:port:token:file transfer protocol:imageUri:targets:transferProtocol:username:password>"},
+	{name: "fleettelemetry", help: "fleettelemetry - show the cached CPU, memory and storage readings of every attached device, no request is sent to the devices\n\tUsage: ./dm fleettelemetry <none>"},
+	{name: "getpowercap", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getpowercap - show the power limit of device, whether it is active, its allowed range and correction time\n\tUsage: ./dm getpowercap <ip address:port:token>"},
+	{name: "setpowercap", layout: "ip:port:token:watts", parts: []int{4}, multiple: true, help: "setpowercap - set the power limit of device within its allowed range, 0 removes the limit\n\tUsage: ./dm setpowercap <ip address:port:token:watts>"},
+	{name: "getpollstatus", layout: "ip:port:token", parts: []int{3}, multiple: true, help: "getpollstatus - show the last poll attempt, the last successful poll and the last poll error of device\n\tUsage: ./dm getpollstatus <ip address:port:token>"},
+	{name: "getpowerhistory", layout: "ip:port:token", parts: []int{3}, help: "getpowerhistory - get the retained power consumption samples of device\n\tUsage: ./dm getpowerhistory <ip address:port:token>"},
+	{name: "help", layout: "command", parts: []int{1}, help: "help - show the usage of a single command\n\tUsage: ./dm help <command>"},
+	{name: "version", help: "version - show the version, git commit and build date of the manager with its Go release and Redfish library version\n\tUsage: ./dm version <none>"},
+	{name: "showconfig", help: "showconfig - show the effective configuration of demotest (config file, command line options and defaults), secrets are redacted\n\tUsage: ./dm showconfig <none>"},
+	{name: "reloadconfig", layout: "path", parts: []int{1}, passthrough: true, help: "reloadconfig - apply a config file without restarting demotest, command line options still take precedence, the manager is dialed again when its address changed and the Kafka consumer reconnects when the broker settings changed, the current config is kept when the new one is invalid or a connection fails, the listen address is applied at restart\n\tUsage: ./dm reloadconfig <config file path>"},
+	{name: "schedule", layout: "cron", parts: []int{1}, passthrough: true, help: "schedule - run a command line at the times of a five field cron expression (minute hour day-of-month month day-of-week) or of a shorthand like @daily, the results are logged and the runs do not drift\n\tUsage: ./dm schedule '<minute> <hour> <day of month> <month> <day of week>' <command> <command arguments>"},
+	{name: "unschedule", layout: "id", parts: []int{1}, multiple: true, help: "unschedule - stop and remove a schedule\n\tUsage: ./dm unschedule <schedule id>"},
+	{name: "listschedules", help: "listschedules - show the schedules with their next run and the result of their last run\n\tUsage: ./dm listschedules <none>"},
+	{name: "listcommands", help: "listcommands - show the usage of all commands\n\tUsage: ./dm listcommands <none>"},
+	{name: "QUIT", exact: true},
+}
+
+//commandAliases maps short names to the canonical command names
+var commandAliases = map[string]string{
+	"ls":       "listcommands",
+	"devices":  "showdevices",
+	"login":    "logindevice",
+	"logout":   "logoutdevice",
+	"gettemp":  "getdevicetemperaturedata",
+	"settemp":  "setdevicetemperaturedata",
+	"getlog":   "getdevicelogdata",
+	"taillog":  "streamdevicelogdata",
+	"accounts": "deviceaccountslist",
+	"access":   "deviceaccess",
+}
+
+//commandError reports a command whose arguments do not match its layout
+type commandError struct {
+	Command string
+	Layout  string
+	Parts   []int
+	Got     int
+	Targets int
+}
+
+func (e *commandError) Error() string {
+	if e.Layout == "" {
+		return fmt.Sprintf("command '%s' expects no arguments, got %d", e.Command, e.Targets)
+	}
+	if e.Targets > 1 {
+		return fmt.Sprintf("command '%s' expects a single %s target, got %d", e.Command, e.Layout, e.Targets)
+	}
+	parts := make([]string, len(e.Parts))
+	for i, part := range e.Parts {
+		parts[i] = strconv.Itoa(part)
+	}
+	return fmt.Sprintf("command '%s' expects %s (%s parts), got %d", e.Command, e.Layout, strings.Join(parts, " or "), e.Got)
+}
+
+//listCommandsUsage returns the usage of every command in table order
+func listCommandsUsage() string {
+	usage := []string{}
+	for _, spec := range commandSpecs {
+		if spec.help != "" {
+			usage = append(usage, spec.help)
+		}
+	}
+	return strings.Join(usage, "\n")
+}
+
+/* resolveCommand() maps an alias or an unambiguous prefix to the canonical command name. Commands marked exact
+ * (QUIT and the commands that reset, delete or detach something) are never matched by a prefix and have to be
+ * typed in full. Names that match nothing are returned unchanged; an ambiguous prefix returns an error listing the
+ * matching commands.
+ */
+func resolveCommand(name string) (string, error) {
+	if name == "" || findCommandSpec(name) != nil {
+		return name, nil
+	}
+	if canonical, ok := commandAliases[name]; ok {
+		return canonical, nil
+	}
+	matches := []string{}
+	for _, spec := range commandSpecs {
+		if !spec.exact && strings.HasPrefix(spec.name, name) {
+			matches = append(matches, spec.name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return name, nil
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("ambiguous command '%s' matches: %s", name, strings.Join(matches, ", "))
+}
+
+/* parseCommandLine() resolves the command of the line and validates its arguments, the targets of a command
+ * starting with the device ip address:port are expanded from groups and address patterns first, the first field
+ * of the other commands such as a group name or a token is kept as it is
+ */
+func parseCommandLine(words []string) (cmd string, targets [][]string, err error) {
+	if cmd, err = resolveCommand(words[0]); err != nil {
+		return "", nil, err
+	}
+	args := words[1:]
+	if spec := findCommandSpec(cmd); spec != nil && !spec.passthrough && strings.HasPrefix(spec.layout, "ip:port") {
+		if args, err = expandGroupTargets(args); err == nil {
+			args, err = expandPatternTargets(args)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	if targets, err = parseCommandArgs(cmd, args); err != nil {
+		return "", nil, err
+	}
+	return cmd, targets, nil
+}
+
+/* expandGroupTargets() replaces every "@group" target, e.g. "@rack1:token", by one target per group member
+ * with the member ip address:port in place of the group name
+ */
+func expandGroupTargets(args []string) ([]string, error) {
+	var groups map[string][]string
+	expanded := []string{}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "@") {
+			expanded = append(expanded, arg)
+			continue
+		}
+		if groups == nil {
+			groupList, err := cc.ListGroups(ctx, new(manager.Empty))
+			if err != nil {
+				return nil, err
+			}
+			groups = make(map[string][]string)
+			for _, group := range groupList.Group {
+				groups[group.Name] = group.IpAddress
+			}
+		}
+		fields := strings.SplitN(strings.TrimPrefix(arg, "@"), ":", 2)
+		members, ok := groups[fields[0]]
+		if !ok {
+			return nil, fmt.Errorf("unknown group '%s'", fields[0])
+		}
+		for _, member := range members {
+			if len(fields) == 2 {
+				expanded = append(expanded, member+":"+fields[1])
+			} else {
+				expanded = append(expanded, member)
+			}
+		}
+	}
+	return expanded, nil
+}
+
+//isAddressPattern reports whether the ip address field of a target is a CIDR (10.0.1.0/24) or a glob (10.0.1.*)
+func isAddressPattern(field string) bool {
+	return strings.Contains(field, "/") || strings.ContainsAny(field, "*?[")
+}
+
+func matchAddressPattern(pattern, ip string) bool {
+	if strings.Contains(pattern, "/") {
+		_, subnet, err := net.ParseCIDR(pattern)
+		return err == nil && subnet.Contains(net.ParseIP(ip))
+	}
+	matched, _ := path.Match(pattern, ip)
+	return matched
+}
+
+/* expandPatternTargets() replaces every target whose ip address is a CIDR or a glob, e.g. "10.0.1.0/24:token",
+ * by one target per attached device matching it with the device ip address:port in place of the pattern
+ */
+func expandPatternTargets(args []string) ([]string, error) {
+	var devices []string
+	expanded := []string{}
+	for _, arg := range args {
+		fields := strings.SplitN(arg, ":", 2)
+		if !isAddressPattern(fields[0]) {
+			expanded = append(expanded, arg)
+			continue
+		}
+		if devices == nil {
+			deviceList, err := cc.GetCurrentDevices(ctx, new(manager.Empty))
+			if err != nil {
+				return nil, err
+			}
+			devices = deviceList.IpAddress
+		}
+		matches := 0
+		for _, device := range devices {
+			ip := strings.Split(device, ":")[0]
+			if !matchAddressPattern(fields[0], ip) {
+				continue
+			}
+			matches++
+			if len(fields) == 2 {
+				expanded = append(expanded, device+":"+fields[1])
+			} else {
+				expanded = append(expanded, device)
+			}
+		}
+		if matches == 0 {
+			return nil, fmt.Errorf("no attached device matches '%s'", fields[0])
+		}
+	}
+	return expanded, nil
+}
+
+/* splitOutputRedirect() removes a trailing "> file" (quoted in the shell, e.g. ./dm getfirmwareinventory ip:port:token '>' fw.txt)
+ * from the command line and returns the file name
+ */
+func splitOutputRedirect(cmdstr string) (string, string) {
+	i := strings.LastIndex(cmdstr, " >")
+	if i < 0 {
+		return cmdstr, ""
+	}
+	file := strings.TrimSpace(cmdstr[i+2:])
+	if file == "" || strings.Contains(file, " ") {
+		return cmdstr, ""
+	}
+	return strings.TrimRight(cmdstr[:i], " "), file
+}
+
+/* outputFilePath() resolves a file name given by dm below GlobalConfig.OutputDir, an absolute path or a path with a
+ * ".." element is rejected so a client can not reach files outside of the directory
+ */
+func outputFilePath(file string) (string, error) {
+	if file == "" || filepath.IsAbs(file) || strings.HasPrefix(file, "/") || strings.HasPrefix(file, "\\") {
+		return "", fmt.Errorf("invalid output file %q, expected a relative path", file)
+	}
+	for _, element := range strings.FieldsFunc(file, func(c rune) bool { return c == '/' || c == '\\' }) {
+		if element == ".." {
+			return "", fmt.Errorf("invalid output file %q, \"..\" is not allowed", file)
+		}
+	}
+	return filepath.Join(GlobalConfig.OutputDir, file), nil
+}
+
+/* createOutputFile() creates the file below GlobalConfig.OutputDir for writing, an existing file is never
+ * overwritten
+ */
+func createOutputFile(file string) (*os.File, error) {
+	filePath, err := outputFilePath(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+}
+
+//writeCommandOutput writes the command result to the file and returns the short confirmation sent to dm instead
+func writeCommandOutput(file, output string) string {
+	outFile, err := createOutputFile(file)
+	if err != nil {
+		return "failed to write output: " + err.Error()
+	}
+	_, err = outFile.Write([]byte(output + "\n"))
+	if closeErr := outFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "failed to write output: " + err.Error()
+	}
+	return fmt.Sprintf("output written to %s (%d bytes)", outFile.Name(), len(output)+1)
+}
+
+//compressSuffix is appended to the command line by "dm -z" to ask for a response with a header byte
+const compressSuffix = " |gzip"
+
+//Header bytes of a negotiated response, a plain response is text ending with ';', a compressed one carries its length
+const (
+	responsePlain      = 'T'
+	responseCompressed = 'Z'
+)
+
+//splitCompressSuffix removes the compression request of dm from the command line
+func splitCompressSuffix(cmdstr string) (string, bool) {
+	if !strings.HasSuffix(cmdstr, compressSuffix) {
+		return cmdstr, false
+	}
+	return strings.TrimSuffix(cmdstr, compressSuffix), true
+}
+
+/* encodeResponse() frames the command result for dm, a result of at least GlobalConfig.CompressMinSize bytes is
+ * gzipped behind the 'Z' header byte and its 4 byte big endian length, a smaller one, or one that fails to compress,
+ * is sent as text behind 'T'
+ */
+func encodeResponse(message string) []byte {
+	if len(message) >= GlobalConfig.CompressMinSize {
+		compressed, err := gzipMessage(message + "\n")
+		if err == nil {
+			response := make([]byte, 5, 5+len(compressed))
+			response[0] = responseCompressed
+			binary.BigEndian.PutUint32(response[1:], uint32(len(compressed)))
+			return append(response, compressed...)
+		}
+		logrus.Errorf("compress response error, sent uncompressed: %s", err)
+	}
+	return []byte(string(responsePlain) + message + "\n" + ";")
+}
+
+func gzipMessage(message string) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write([]byte(message)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+/* parseWindowTime() reads a maintenance window bound, "now", Unix seconds, or a duration (e.g. "2h") after base,
+ * and returns it in Unix seconds
+ */
+func parseWindowTime(value string, base time.Time) (int64, error) {
+	if value == "now" {
+		return time.Now().Unix(), nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %s, expected now, Unix seconds or a duration", value)
+	}
+	return base.Add(duration).Unix(), nil
+}
+
+func findCommandSpec(name string) *commandSpec {
+	for i := range commandSpecs {
+		if commandSpecs[i].name == name {
+			return &commandSpecs[i]
+		}
+	}
+	return nil
+}
+
+/* parseCommandArgs() splits each target of the command into its ':' separated fields and checks them
+ * against the command layout. Unknown commands are not validated here.
+ */
+func parseCommandArgs(name string, args []string) (targets [][]string, err error) {
+	spec := findCommandSpec(name)
+	if spec == nil {
+		return nil, nil
+	}
+	if spec.passthrough && len(args) > 1 {
+		args = args[:1]
+	}
+	for _, arg := range args {
+		if arg == "" {
+			continue
+		}
+		if spec.passthrough {
+			//the last field of a passthrough command keeps its ':'
+			targets = append(targets, strings.SplitN(arg, ":", strings.Count(spec.layout, ":")+1))
+		} else {
+			targets = append(targets, strings.Split(arg, ":"))
+		}
+	}
+	if spec.parts == nil {
+		if len(targets) != 0 {
+			return nil, &commandError{Command: name, Targets: len(targets)}
+		}
+		return nil, nil
+	}
+	if len(targets) == 0 {
+		return nil, &commandError{Command: name, Layout: spec.layout, Parts: spec.parts}
+	}
+	if !spec.multiple && len(targets) > 1 {
+		return nil, &commandError{Command: name, Layout: spec.layout, Parts: spec.parts, Targets: len(targets)}
+	}
+	for _, target := range targets {
+		valid := false
+		for _, part := range spec.parts {
+			if len(target) == part {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, &commandError{Command: name, Layout: spec.layout, Parts: spec.parts, Got: len(target)}
+		}
+	}
+	return targets, nil
+}
@@ -24,6 +24,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -40,31 +41,59 @@ import (
 
 //GlobalConfigSpec ...
 type GlobalConfigSpec struct {
-	Kafka    string `yaml:"kafka"`
-	Local    string `yaml:"local"`
-	Manager  string `yaml:"manager"`
-	Topic    string `yaml:"topic"`
-	Consumer bool   `yaml:"consumer"`
+	Kafka              string `yaml:"kafka"`
+	Local              string `yaml:"local"`
+	Manager            string `yaml:"manager"`
+	Topic              string `yaml:"topic"`
+	Consumer           bool   `yaml:"consumer"`
+	SchemaVersion      int    `yaml:"schemaversion"`
+	KafkaTLS           bool   `yaml:"kafkatls"`
+	KafkaTLSCA         string `yaml:"kafkatlsca"`
+	KafkaTLSCert       string `yaml:"kafkatlscert"`
+	KafkaTLSKey        string `yaml:"kafkatlskey"`
+	KafkaSASLMechanism string `yaml:"kafkasaslmechanism"`
+	KafkaUsername      string `yaml:"kafkausername"`
+	KafkaPassword      string `yaml:"kafkapassword"`
+	KafkaGroup         string `yaml:"kafkagroup"`
+	RetryAttempts      int    `yaml:"retryattempts"`
+	RetryBackoff       int    `yaml:"retrybackoff"`
+	CompressMinSize    int    `yaml:"compressminsize"`
+	LogFile            string `yaml:"logfile"`
+	LogMaxSizeMB       int    `yaml:"logmaxsizemb"`
+	CredStore          string `yaml:"credstore"`
+	CredStoreKey       string `yaml:"credstorekey"`
+	MaxRecvMsgSize     int    `yaml:"maxrecvmsgsize"`
+	OutputDir          string `yaml:"outputdir"`
 }
 
 //CharReplacer ...
 var (
 	CharReplacer = strings.NewReplacer("\\t", "\t", "\\n", "\n")
-	//GlobalConfig ...
-	GlobalConfig = GlobalConfigSpec{
-		Kafka:    "kafka_ip.sh",
-		Local:    ":9999",
-		Manager:  "localhost:31085",
-		Topic:    managerTopic,
-		Consumer: false,
+	//defaultGlobalConfig holds the settings used when neither the config file nor the command line sets them
+	defaultGlobalConfig = GlobalConfigSpec{
+		Kafka:           "kafka_ip.sh",
+		Local:           ":9999",
+		Manager:         "localhost:31085",
+		Topic:           managerTopic,
+		Consumer:        false,
+		RetryAttempts:   3,
+		RetryBackoff:    500,
+		CompressMinSize: 1024,
+		LogMaxSizeMB:    100,
+		MaxRecvMsgSize:  4 * 1024 * 1024,
+		OutputDir:       "output",
 	}
+	//GlobalConfig ...
+	GlobalConfig  = defaultGlobalConfig
 	GlobalOptions struct {
-		Config   string `short:"c" long:"config" env:"PROXYCONFIG" value-name:"FILE" default:"" description:"Location of proxy config file"`
-		Kafka    string `short:"k" long:"kafka" default:"" value-name:"SERVER:PORT" description:"IP/Host and port of Kafka"`
-		Manager  string `short:"i" long:"manager" default:"" value-name:"SERVER:PORT" description:"IP/Host and port of Manager"`
-		Local    string `short:"l" long:"local" default:"" value-name:"SERVER:PORT" description:"IP/Host and port to listen on"`
-		Topic    string `short:"t" long:"topic" default:"manager" value-name:"string" description:"Receiving Kafka message by the topic"`
-		Consumer bool   `short:"s" long:"consumer" value-name:"" description:"Trun on/off Kafka Consumer"`
+		Config        string `short:"c" long:"config" env:"PROXYCONFIG" value-name:"FILE" default:"" description:"Location of proxy config file"`
+		Kafka         string `short:"k" long:"kafka" default:"" value-name:"SERVER:PORT" description:"IP/Host and port of Kafka"`
+		Manager       string `short:"i" long:"manager" default:"" value-name:"SERVER:PORT" description:"IP/Host and port of Manager"`
+		Local         string `short:"l" long:"local" default:"" value-name:"SERVER:PORT" description:"IP/Host and port to listen on"`
+		Topic         string `short:"t" long:"topic" default:"manager" value-name:"string" description:"Receiving Kafka message by the topic"`
+		Consumer      bool   `short:"s" long:"consumer" value-name:"" description:"Trun on/off Kafka Consumer"`
+		KafkaGroup    string `short:"g" long:"kafkagroup" default:"" value-name:"GROUP" description:"Kafka consumer group shared by the demotest instances, none for a single consumer"`
+		SchemaVersion int    `short:"v" long:"schemaversion" default:"0" value-name:"VERSION" description:"Schema version of the manager JSON documents, 0 for the current one"`
 	}
 	Debug = log.New(os.Stdout, "DEBUG: ", 0)
 	Info  = log.New(os.Stdout, "INFO: ", 0)
@@ -106,31 +135,76 @@ func ProcessGlobalOptions() {
 		GlobalOptions.Config = filepath.Join(home, ".redfish-manager", "demotest-config")
 	}
 	if info, err := os.Stat(GlobalOptions.Config); err == nil && !info.IsDir() {
-		configFile, err := ioutil.ReadFile(GlobalOptions.Config)
-		if err != nil {
-			Error.Fatalf("Unable to read the configuration file '%s': %s",
-				GlobalOptions.Config, err.Error())
-		}
-		if err = yaml.Unmarshal(configFile, &GlobalConfig); err != nil {
-			Error.Fatalf("Unable to parse the configuration file '%s': %s",
-				GlobalOptions.Config, err.Error())
+		if err = readConfigFile(GlobalOptions.Config, &GlobalConfig); err != nil {
+			Error.Fatal(err)
 		}
 	}
+	applyGlobalOptions(&GlobalConfig)
+	if err := validateConfig(GlobalConfig); err != nil {
+		Error.Fatal(err)
+	}
+}
+
+//readConfigFile overlays the settings of the YAML config file on config
+func readConfigFile(file string, config *GlobalConfigSpec) error {
+	configFile, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("Unable to read the configuration file '%s': %s", file, err.Error())
+	}
+	if err = yaml.Unmarshal(configFile, config); err != nil {
+		return fmt.Errorf("Unable to parse the configuration file '%s': %s", file, err.Error())
+	}
+	return nil
+}
+
+//applyGlobalOptions overrides the settings of config with the options given on the command line
+func applyGlobalOptions(config *GlobalConfigSpec) {
 	if GlobalOptions.Kafka != "" {
-		GlobalConfig.Kafka = GlobalOptions.Kafka
+		config.Kafka = GlobalOptions.Kafka
 	}
 	if GlobalOptions.Local != "" {
-		GlobalConfig.Local = GlobalOptions.Local
+		config.Local = GlobalOptions.Local
 	}
 	if GlobalOptions.Manager != "" {
-		GlobalConfig.Manager = GlobalOptions.Manager
+		config.Manager = GlobalOptions.Manager
 	}
 	if GlobalOptions.Topic != "" {
-		GlobalConfig.Topic = GlobalOptions.Topic
+		config.Topic = GlobalOptions.Topic
 	}
 	if GlobalOptions.Consumer != false {
-		GlobalConfig.Consumer = GlobalOptions.Consumer
+		config.Consumer = GlobalOptions.Consumer
+	}
+	if GlobalOptions.KafkaGroup != "" {
+		config.KafkaGroup = GlobalOptions.KafkaGroup
+	}
+	if GlobalOptions.SchemaVersion != 0 {
+		config.SchemaVersion = GlobalOptions.SchemaVersion
+	}
+}
+
+//validateConfig checks the settings demotest can not work without
+func validateConfig(config GlobalConfigSpec) error {
+	switch {
+	case config.Manager == "":
+		return errors.New("manager address is empty")
+	case config.Local == "":
+		return errors.New("listen address is empty")
+	case config.Topic == "":
+		return errors.New("Kafka topic is empty")
+	case config.Consumer && config.Kafka == "":
+		return errors.New("Kafka address is empty while the consumer is on")
+	case config.SchemaVersion < 0:
+		return fmt.Errorf("invalid schema version %d", config.SchemaVersion)
+	case config.RetryAttempts < 0 || config.RetryBackoff < 0:
+		return fmt.Errorf("invalid retry attempts %d backoff %d", config.RetryAttempts, config.RetryBackoff)
+	case config.CompressMinSize < 0:
+		return fmt.Errorf("invalid compress min size %d", config.CompressMinSize)
+	case config.MaxRecvMsgSize <= 0:
+		return fmt.Errorf("invalid max receive message size %d", config.MaxRecvMsgSize)
+	case config.OutputDir == "":
+		return errors.New("output directory is empty")
 	}
+	return nil
 }
 
 //ShowGlobalOptions ...
@@ -138,8 +212,49 @@ func ShowGlobalOptions() {
 	log.Printf("Configuration:")
 	if GlobalConfig.Consumer {
 		log.Printf("    Kafka: %v", GlobalConfig.Kafka)
+		if GlobalConfig.KafkaGroup != "" {
+			log.Printf("    Kafka Consumer Group: %v", GlobalConfig.KafkaGroup)
+		}
+		if GlobalConfig.KafkaSASLMechanism != "" {
+			log.Printf("    Kafka SASL: %v user %v", GlobalConfig.KafkaSASLMechanism, GlobalConfig.KafkaUsername)
+		}
+		if GlobalConfig.KafkaTLS || GlobalConfig.KafkaTLSCA != "" || GlobalConfig.KafkaTLSCert != "" {
+			log.Printf("    Kafka TLS CA: %v", GlobalConfig.KafkaTLSCA)
+		}
 	}
 	log.Printf("    Listen Address: %v", GlobalConfig.Local)
+	if GlobalConfig.SchemaVersion != 0 {
+		log.Printf("    Schema Version: %v", GlobalConfig.SchemaVersion)
+	}
+	log.Printf("    Retry Attempts: %v backoff %vms", GlobalConfig.RetryAttempts, GlobalConfig.RetryBackoff)
+	log.Printf("    Compress Min Size: %v", GlobalConfig.CompressMinSize)
+	log.Printf("    Max Receive Message Size: %v", GlobalConfig.MaxRecvMsgSize)
+	log.Printf("    Output Directory: %v", GlobalConfig.OutputDir)
+	if GlobalConfig.CredStore != "" {
+		log.Printf("    Credential Store: %v", GlobalConfig.CredStore)
+	}
+	if GlobalConfig.LogFile != "" {
+		log.Printf("    Log File: %v rotated at %vMB", GlobalConfig.LogFile, GlobalConfig.LogMaxSizeMB)
+	}
+}
+
+//redactedSecret replaces a configured secret in the output of showconfig
+const redactedSecret = "******"
+
+//showConfig returns the effective GlobalConfig in the YAML layout of the config file, with secrets redacted
+func showConfig() string {
+	config := GlobalConfig
+	if config.KafkaPassword != "" {
+		config.KafkaPassword = redactedSecret
+	}
+	if config.CredStoreKey != "" {
+		config.CredStoreKey = redactedSecret
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "failed to show config: " + err.Error()
+	}
+	return "# " + GlobalOptions.Config + "\n" + strings.TrimSuffix(string(data), "\n")
 }
 
 func runCommand(program string) string {
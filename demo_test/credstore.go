@@ -0,0 +1,132 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+//deviceCredentials holds the login of a device kept in the credential store
+type deviceCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+/* credStoreCipher() returns the AES-256-GCM cipher of the credential store, keyed by the SHA-256 of
+ * GlobalConfig.CredStoreKey
+ */
+func credStoreCipher() (cipher.AEAD, error) {
+	if GlobalConfig.CredStore == "" || GlobalConfig.CredStoreKey == "" {
+		return nil, errors.New("credential store is not configured, set credstore and credstorekey")
+	}
+	key := sha256.Sum256([]byte(GlobalConfig.CredStoreKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+/* loadCredStore() decrypts the credentials keyed by ip:port from GlobalConfig.CredStore, a missing store is empty
+ */
+func loadCredStore() (map[string]deviceCredentials, error) {
+	gcm, err := credStoreCipher()
+	if err != nil {
+		return nil, err
+	}
+	store := map[string]deviceCredentials{}
+	data, err := ioutil.ReadFile(GlobalConfig.CredStore)
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("credential store " + GlobalConfig.CredStore + " is corrupted")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("credential store " + GlobalConfig.CredStore + " can not be decrypted, wrong credstorekey?")
+	}
+	if err = json.Unmarshal(plain, &store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+/* saveCredStore() encrypts the credentials with a new nonce and replaces GlobalConfig.CredStore, readable by the
+ * owner only
+ */
+func saveCredStore(store map[string]deviceCredentials) error {
+	gcm, err := credStoreCipher()
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(store)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(GlobalConfig.CredStore), 0700); err != nil {
+		return err
+	}
+	tmpFile := GlobalConfig.CredStore + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, gcm.Seal(nonce, nonce, plain, nil), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, GlobalConfig.CredStore)
+}
+
+//storeCredentials adds or replaces the credentials of the device ip:port in the credential store
+func storeCredentials(ipAddress, username, password string) error {
+	store, err := loadCredStore()
+	if err != nil {
+		return err
+	}
+	store[ipAddress] = deviceCredentials{Username: username, Password: password}
+	return saveCredStore(store)
+}
+
+//lookupCredentials returns the stored credentials of the device ip:port
+func lookupCredentials(ipAddress string) (deviceCredentials, error) {
+	store, err := loadCredStore()
+	if err != nil {
+		return deviceCredentials{}, err
+	}
+	credentials, ok := store[ipAddress]
+	if !ok {
+		return deviceCredentials{}, errors.New("no stored credentials for " + ipAddress)
+	}
+	return credentials, nil
+}
@@ -26,15 +26,28 @@ import "bufio"
 import "os"
 import "strings"
 import "log"
+import "io"
+import "compress/gzip"
+import "encoding/binary"
 
 func main() {
 	if len(os.Args) <= 1 {
-		log.Printf("Syntax: ./dm <arguments>")
+		log.Printf("Syntax: ./dm [-z] <arguments>")
 		os.Exit(-1)
 	}
 
+	// "-z" asks for a gzip compressed response of large results, sent behind a header byte
+	compress := os.Args[1] == "-z"
+	args := os.Args[1:]
+	if compress {
+		args = os.Args[2:]
+	}
+
 	// connect to this socket
-	cmdstr := strings.Join(os.Args[1:], " ")
+	cmdstr := strings.Join(args, " ")
+	if compress {
+		cmdstr = cmdstr + " |gzip"
+	}
 	conn, err := net.Dial("tcp", "127.0.0.1:9999")
 	if err != nil {
 		log.Printf("Error opening connection: %v", err)
@@ -44,13 +57,41 @@ func main() {
 	// send to socket
 	fmt.Fprintf(conn, cmdstr+"\n")
 
-	// listen for reply
-	message, err := bufio.NewReader(conn).ReadString(';')
-	if err != nil {
-		log.Printf("Error reading result: %v", err)
-		os.Exit(-1)
+	// listen for reply, printing each line as it arrives so streaming commands show up immediately
+	reader := bufio.NewReader(conn)
+	if compress {
+		header, err := reader.ReadByte()
+		if err != nil {
+			log.Printf("Error reading result: %v", err)
+			os.Exit(-1)
+		}
+		if header == 'Z' {
+			var length uint32
+			if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+				log.Printf("Error reading result: %v", err)
+				os.Exit(-1)
+			}
+			gz, err := gzip.NewReader(io.LimitReader(reader, int64(length)))
+			if err != nil {
+				log.Printf("Error reading result: %v", err)
+				os.Exit(-1)
+			}
+			if _, err := io.Copy(os.Stdout, gz); err != nil {
+				log.Printf("Error reading result: %v", err)
+				os.Exit(-1)
+			}
+			return
+		}
+	}
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			log.Printf("Error reading result: %v", err)
+			os.Exit(-1)
+		}
+		if r == ';' {
+			break
+		}
+		fmt.Print(string(r))
 	}
-
-	message = strings.TrimSuffix(message, ";")
-	fmt.Print(message)
 }
@@ -0,0 +1,272 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	logrus "github.com/sirupsen/logrus"
+)
+
+var (
+	kafkaIP     string
+	kafkaIPLock sync.Mutex
+	//kafkaCancel stops the running Kafka consumer
+	kafkaCancel context.CancelFunc
+)
+
+//kafkaAddress returns the Kafka broker address, looked up by kafka_ip.sh when it is configured
+func kafkaAddress() string {
+	kafkaIPLock.Lock()
+	defer kafkaIPLock.Unlock()
+	if kafkaIP == "" {
+		//the consumer goroutine reconnects here while reloadconfig may replace GlobalConfig
+		managerLock.RLock()
+		kafka := GlobalConfig.Kafka
+		managerLock.RUnlock()
+		if kafka == "kafka_ip.sh" {
+			kafkaIP = runCommand(kafka) + ":9092"
+			logrus.Info("IP address of kafka-cord-0: ", kafkaIP)
+		} else {
+			kafkaIP = kafka
+		}
+	}
+	return kafkaIP
+}
+
+//resetKafkaAddress makes the next kafkaAddress call look the broker address up again from GlobalConfig
+func resetKafkaAddress() {
+	kafkaIPLock.Lock()
+	kafkaIP = ""
+	kafkaIPLock.Unlock()
+}
+
+/* kafkaTLSConfig() builds the TLS config of the Kafka connection, the CA verifies the brokers and the client
+ * certificate and key are sent when the brokers require mutual TLS
+ */
+func kafkaTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if GlobalConfig.KafkaTLSCA != "" {
+		caCert, err := ioutil.ReadFile(GlobalConfig.KafkaTLSCA)
+		if err != nil {
+			return nil, err
+		}
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no PEM certificate found in Kafka CA '%s'", GlobalConfig.KafkaTLSCA)
+		}
+		tlsConfig.RootCAs = caPool
+	}
+	if GlobalConfig.KafkaTLSCert != "" || GlobalConfig.KafkaTLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(GlobalConfig.KafkaTLSCert, GlobalConfig.KafkaTLSKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+/* newKafkaConfig() returns the sarama config carrying the TLS and SASL settings of GlobalConfig. PLAIN is the only
+ * SASL mechanism supported, as SCRAM needs a client implementation sarama does not ship, and it is refused without
+ * TLS since it sends the password in clear text
+ */
+func newKafkaConfig() (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	if GlobalConfig.KafkaTLS || GlobalConfig.KafkaTLSCA != "" || GlobalConfig.KafkaTLSCert != "" {
+		tlsConfig, err := kafkaTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+	if GlobalConfig.KafkaSASLMechanism != "" {
+		if GlobalConfig.KafkaSASLMechanism != sarama.SASLTypePlaintext {
+			return nil, fmt.Errorf("unsupported Kafka SASL mechanism '%s', supported: %s", GlobalConfig.KafkaSASLMechanism, sarama.SASLTypePlaintext)
+		}
+		if GlobalConfig.KafkaUsername == "" || GlobalConfig.KafkaPassword == "" {
+			return nil, errors.New("Kafka SASL needs a username and a password")
+		}
+		if !config.Net.TLS.Enable {
+			return nil, errors.New("Kafka SASL PLAIN sends the password in clear text, it needs kafkatls")
+		}
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(GlobalConfig.KafkaSASLMechanism)
+		config.Net.SASL.User = GlobalConfig.KafkaUsername
+		config.Net.SASL.Password = GlobalConfig.KafkaPassword
+	}
+	return config, nil
+}
+
+//topicGroupHandler logs the messages of the partitions the consumer group assigns to this instance
+type topicGroupHandler struct {
+	group string
+}
+
+func (handler topicGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	logrus.Infof("Consumer group %s claims %v", handler.group, session.Claims())
+	return nil
+}
+
+func (topicGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (topicGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		logrus.Infof("Got message on topic=[%s]: %s", msg.Topic, string(msg.Value))
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+/* topicGroupListener() joins the consumer group groupName, the instances of a group share the partitions of the
+ * topic so each message is handled by one instance only, until ctx is cancelled
+ */
+func topicGroupListener(ctx context.Context, topic string, groupName string, group sarama.ConsumerGroup) {
+	logrus.Info("Starting topicGroupListener for ", topic, " in group ", groupName)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		for err := range group.Errors() {
+			logrus.Errorf("Consumer group error: %s", err)
+		}
+	}()
+	go func() {
+		select {
+		case <-signals:
+			logrus.Warn("Interrupt is detected")
+			group.Close()
+			os.Exit(1)
+		case <-ctx.Done():
+			signal.Stop(signals)
+		}
+	}()
+	defer group.Close()
+	backoff := kafkaRetryMinBackoff
+	for {
+		//Consume returns at every rebalance of the group and has to be called again, the group resumes from its committed offsets
+		err := group.Consume(ctx, []string{topic}, topicGroupHandler{group: groupName})
+		if ctx.Err() != nil {
+			logrus.Info("Stopped topicGroupListener for ", topic)
+			return
+		}
+		if err == nil {
+			backoff = kafkaRetryMinBackoff
+			continue
+		}
+		if err == sarama.ErrClosedConsumerGroup {
+			return
+		}
+		logrus.Errorf("topicGroupListener error, topic=[%s]: %s, retrying in %v", topic, err.Error(), backoff)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > kafkaRetryMaxBackoff {
+			backoff = kafkaRetryMaxBackoff
+		}
+	}
+}
+
+/* startKafka() consumes GlobalConfig.Topic, alone or in the consumer group GlobalConfig.KafkaGroup, until
+ * stopKafka() is called
+ */
+func startKafka() error {
+	config, err := newKafkaConfig()
+	if err != nil {
+		return err
+	}
+	config.Consumer.Return.Errors = true
+	ctx, cancel := context.WithCancel(context.Background())
+	if GlobalConfig.KafkaGroup != "" {
+		config.Version = sarama.V2_0_0_0
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+		group, err := sarama.NewConsumerGroup([]string{kafkaAddress()}, GlobalConfig.KafkaGroup, config)
+		if err != nil {
+			cancel()
+			return err
+		}
+		go topicGroupListener(ctx, GlobalConfig.Topic, GlobalConfig.KafkaGroup, group)
+	} else {
+		go topicListener(ctx, GlobalConfig.Topic, config)
+	}
+	kafkaCancel = cancel
+	return nil
+}
+
+//stopKafka stops the consumer started by startKafka, if any
+func stopKafka() {
+	if kafkaCancel != nil {
+		kafkaCancel()
+		kafkaCancel = nil
+	}
+}
+
+/* publishTestEvent() sends a synthetic device event to the topic with a sync producer, the event carries
+ * "Synthetic": true so consumers can tell it from the events of real devices
+ */
+func publishTestEvent(topic, message string) string {
+	if topic == "" {
+		topic = GlobalConfig.Topic
+	}
+	config, err := newKafkaConfig()
+	if err != nil {
+		return "Kafka config error: " + err.Error()
+	}
+	config.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer([]string{kafkaAddress()}, config)
+	if err != nil {
+		logrus.Errorf("Kafka producer error: %s", err)
+		return "Kafka producer error: " + err.Error()
+	}
+	defer producer.Close()
+	event := map[string]interface{}{
+		"Synthetic":      true,
+		"Source":         "demotest",
+		"EventType":      "TestEvent",
+		"EventTimestamp": time.Now().UTC().Format(time.RFC3339),
+		"Message":        message,
+	}
+	if GlobalConfig.SchemaVersion != 0 {
+		event["SchemaVersion"] = GlobalConfig.SchemaVersion
+	}
+	value, _ := json.Marshal(event)
+	partition, offset, err := producer.SendMessage(&sarama.ProducerMessage{Topic: topic, Value: sarama.ByteEncoder(value)})
+	if err != nil {
+		logrus.Errorf("publish test event error, topic=[%s]: %s", topic, err)
+		return "publish test event error: " + err.Error()
+	}
+	return fmt.Sprintf("test event published to %s partition %d offset %d", topic, partition, offset)
+}
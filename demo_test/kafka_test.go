@@ -0,0 +1,52 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import "testing"
+
+func TestNewKafkaConfigSASL(t *testing.T) {
+	saved := GlobalConfig
+	defer func() { GlobalConfig = saved }()
+	tests := []struct {
+		name      string
+		mechanism string
+		tls       bool
+		wantErr   bool
+	}{
+		{"PLAIN over TLS", "PLAIN", true, false},
+		{"PLAIN without TLS", "PLAIN", false, true},
+		{"SCRAM is not supported", "SCRAM-SHA-256", true, true},
+	}
+	for _, tt := range tests {
+		GlobalConfig = defaultGlobalConfig
+		GlobalConfig.KafkaSASLMechanism, GlobalConfig.KafkaTLS = tt.mechanism, tt.tls
+		GlobalConfig.KafkaUsername, GlobalConfig.KafkaPassword = "demotest", "secret"
+		config, err := newKafkaConfig()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: newKafkaConfig() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (!config.Net.SASL.Enable || !config.Net.TLS.Enable) {
+			t.Errorf("%s: SASL %v TLS %v, want both enabled", tt.name, config.Net.SASL.Enable, config.Net.TLS.Enable)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//logFileBackups is the number of rotated log files kept next to GlobalConfig.LogFile
+const logFileBackups = 5
+
+//rotatingFile appends to a log file and renames it to <file>.1 once it would grow past maxSize bytes
+type rotatingFile struct {
+	path    string
+	maxSize int64
+	size    int64
+	file    *os.File
+}
+
+func openRotatingFile(path string, maxSizeMB int) (*rotatingFile, error) {
+	if maxSizeMB <= 0 {
+		return nil, fmt.Errorf("invalid log max size %dMB", maxSizeMB)
+	}
+	r := &rotatingFile{path: path, maxSize: int64(maxSizeMB) * 1024 * 1024}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+/* rotate() shifts <file>.1 ... <file>.N-1 up by one, dropping the oldest, and starts a new file
+ */
+func (r *rotatingFile) rotate() error {
+	r.file.Close()
+	for i := logFileBackups - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) Close() error {
+	return r.file.Close()
+}
+
+//logFileHook writes every logrus entry as a JSON line to the rotating log file, if one is configured
+type logFileHook struct {
+	lock      sync.Mutex
+	file      *rotatingFile
+	formatter logrus.Formatter
+}
+
+var logFile = &logFileHook{formatter: &logrus.JSONFormatter{}}
+
+func (h *logFileHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *logFileHook) Fire(entry *logrus.Entry) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.file == nil {
+		return nil
+	}
+	data, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.file.Write(data)
+	return err
+}
+
+/* setupLogFile() sends the logrus entries to the file as well as to stdout, rotating it at maxSizeMB, an empty
+ * path closes the current log file and logs to stdout only
+ */
+func setupLogFile(path string, maxSizeMB int) error {
+	var file *rotatingFile
+	if path != "" {
+		var err error
+		if file, err = openRotatingFile(path, maxSizeMB); err != nil {
+			return err
+		}
+	}
+	logFile.lock.Lock()
+	old := logFile.file
+	logFile.file = file
+	logFile.lock.Unlock()
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
+func init() {
+	logrus.AddHook(logFile)
+}
@@ -0,0 +1,195 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	manager "devicemanager/demo_test/proto"
+	logrus "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+//managerDialTimeout bounds the wait for the manager at a reloaded address
+const managerDialTimeout = 5 * time.Second
+
+var (
+	//managerLock guards conn, cc, ctx and GlobalConfig against reloadconfig for the log streams running on goroutines of their own
+	managerLock sync.RWMutex
+	//connUsers counts the log streams using conn, reloadconfig closes a replaced conn once they are done
+	connUsers = new(sync.WaitGroup)
+)
+
+/* acquireManager() returns the current manager client and context for a log stream, release has to be called
+ * when the stream no longer uses them
+ */
+func acquireManager() (client manager.DeviceManagementClient, managerCtx context.Context, release func()) {
+	managerLock.RLock()
+	defer managerLock.RUnlock()
+	users := connUsers
+	users.Add(1)
+	return cc, ctx, users.Done
+}
+
+/* replaceManagerConn() makes the manager calls use newConn, the replaced conn is closed once the log streams
+ * using it released it
+ */
+func replaceManagerConn(newConn *grpc.ClientConn) {
+	managerLock.Lock()
+	oldConn, oldUsers := conn, connUsers
+	conn, connUsers = newConn, new(sync.WaitGroup)
+	cc = manager.NewDeviceManagementClient(conn)
+	managerLock.Unlock()
+	go func() {
+		oldUsers.Wait()
+		oldConn.Close()
+	}()
+}
+
+//setGlobalConfig replaces GlobalConfig under managerLock
+func setGlobalConfig(config GlobalConfigSpec) {
+	managerLock.Lock()
+	GlobalConfig = config
+	managerLock.Unlock()
+}
+
+/* dialManager() connects to the manager at address, it waits until the manager answers when block is set.
+ * Responses up to GlobalConfig.MaxRecvMsgSize bytes are accepted.
+ */
+func dialManager(address string, block bool) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithUnaryInterceptor(retryInterceptor),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(GlobalConfig.MaxRecvMsgSize))}
+	if !block {
+		return grpc.Dial(address, opts...)
+	}
+	dialCtx, cancel := context.WithTimeout(context.Background(), managerDialTimeout)
+	defer cancel()
+	return grpc.DialContext(dialCtx, address, append(opts, grpc.WithBlock())...)
+}
+
+/* managerContext() returns the context of the manager calls, carrying the schema version when one is configured
+ */
+func managerContext() context.Context {
+	managerCtx := context.Background()
+	if GlobalConfig.SchemaVersion != 0 {
+		managerCtx = metadata.AppendToOutgoingContext(managerCtx, "schema-version", strconv.Itoa(GlobalConfig.SchemaVersion))
+	}
+	return managerCtx
+}
+
+/* kafkaSettingsChanged() reports whether the consumer has to reconnect to apply config
+ */
+func kafkaSettingsChanged(old, config GlobalConfigSpec) bool {
+	return old.Kafka != config.Kafka || old.Topic != config.Topic || old.Consumer != config.Consumer ||
+		old.KafkaGroup != config.KafkaGroup || old.KafkaTLS != config.KafkaTLS || old.KafkaTLSCA != config.KafkaTLSCA ||
+		old.KafkaTLSCert != config.KafkaTLSCert || old.KafkaTLSKey != config.KafkaTLSKey ||
+		old.KafkaSASLMechanism != config.KafkaSASLMechanism || old.KafkaUsername != config.KafkaUsername ||
+		old.KafkaPassword != config.KafkaPassword
+}
+
+/* reloadConfig() applies the config file to the running demotest, the command line options still take
+ * precedence. The manager is dialed again when its address or the max receive message size changed and the Kafka
+ * consumer reconnects when the broker settings changed; the previous config and connections are kept when the new
+ * config is invalid or a connection fails. The log file is reopened when it changed, the listen address can only
+ * change at restart.
+ */
+func reloadConfig(file string) string {
+	config := defaultGlobalConfig
+	if err := readConfigFile(file, &config); err != nil {
+		return "reload config failed: " + err.Error()
+	}
+	applyGlobalOptions(&config)
+	if err := validateConfig(config); err != nil {
+		return "reload config failed: " + err.Error()
+	}
+	var notes []string
+	old := GlobalConfig
+	if config.Local != old.Local {
+		notes = append(notes, "listen address "+config.Local+" is applied at restart, still listening on "+old.Local)
+		config.Local = old.Local
+	}
+	setGlobalConfig(config)
+	if _, err := newKafkaConfig(); err != nil {
+		setGlobalConfig(old)
+		return "reload config failed: Kafka config error: " + err.Error()
+	}
+	logFileChanged := config.LogFile != old.LogFile || config.LogMaxSizeMB != old.LogMaxSizeMB
+	if logFileChanged {
+		if err := setupLogFile(config.LogFile, config.LogMaxSizeMB); err != nil {
+			setGlobalConfig(old)
+			return "reload config failed: could not open log file: " + err.Error()
+		}
+	}
+	rollback := func() {
+		setGlobalConfig(old)
+		if logFileChanged {
+			if err := setupLogFile(old.LogFile, old.LogMaxSizeMB); err != nil {
+				logrus.Errorf("log file could not be restored: %v", err)
+			}
+		}
+	}
+	var newConn *grpc.ClientConn
+	if config.Manager != old.Manager || config.MaxRecvMsgSize != old.MaxRecvMsgSize {
+		var err error
+		if newConn, err = dialManager(config.Manager, true); err != nil {
+			rollback()
+			return "reload config failed: could not connect to manager " + config.Manager + ": " + err.Error()
+		}
+	}
+	if kafkaSettingsChanged(old, config) {
+		stopKafka()
+		resetKafkaAddress()
+		if config.Consumer {
+			if err := startKafka(); err != nil {
+				rollback()
+				resetKafkaAddress()
+				if old.Consumer {
+					if err := startKafka(); err != nil {
+						logrus.Errorf("Kafka consumer could not be restored: %v", err)
+					}
+				}
+				if newConn != nil {
+					newConn.Close()
+				}
+				return "reload config failed: Kafka init error: " + err.Error()
+			}
+			notes = append(notes, "Kafka consumer reconnected to "+kafkaAddress()+" topic "+config.Topic)
+		} else if old.Consumer {
+			notes = append(notes, "Kafka consumer stopped")
+		}
+	}
+	if newConn != nil {
+		replaceManagerConn(newConn)
+		notes = append(notes, "manager reconnected to "+config.Manager)
+	}
+	managerLock.Lock()
+	ctx = managerContext()
+	managerLock.Unlock()
+	GlobalOptions.Config = file
+	logrus.Info("Config reloaded from ", file)
+	return strings.Join(append([]string{"config reloaded from " + file}, notes...), "\n")
+}
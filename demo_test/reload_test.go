@@ -0,0 +1,61 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	manager "devicemanager/demo_test/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestReplaceManagerConnWaitsForStreams(t *testing.T) {
+	oldConn, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	newConn, err := grpc.Dial("127.0.0.1:2", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer newConn.Close()
+	conn, cc = oldConn, manager.NewDeviceManagementClient(oldConn)
+	_, _, release := acquireManager()
+	replaceManagerConn(newConn)
+	if conn != newConn {
+		t.Fatal("conn was not replaced")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if state := oldConn.GetState(); state == connectivity.Shutdown {
+		t.Fatal("old conn closed while a stream still uses it")
+	}
+	release()
+	deadline := time.Now().Add(time.Second)
+	for oldConn.GetState() != connectivity.Shutdown {
+		if time.Now().After(deadline) {
+			t.Fatal("old conn not closed after the stream released it")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
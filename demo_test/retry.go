@@ -0,0 +1,117 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/* retryableMethods are the read-only manager calls, a call changing the device or the manager (a reset, an
+ * update, a bank switch, ...) is never sent twice since the first attempt may have been applied
+ */
+var retryableMethods = map[string]bool{
+	"/manager.device_management/GetCurrentDevices":           true,
+	"/manager.device_management/GetVersion":                  true,
+	"/manager.device_management/GetFleetTelemetry":           true,
+	"/manager.device_management/ListGroups":                  true,
+	"/manager.device_management/ListDeviceAccounts":          true,
+	"/manager.device_management/GetSessionService":           true,
+	"/manager.device_management/GetAccountServicePolicy":     true,
+	"/manager.device_management/GetDeviceLogData":            true,
+	"/manager.device_management/ListLogServices":             true,
+	"/manager.device_management/GetUpdateServiceStatus":      true,
+	"/manager.device_management/GetFirmwareInventory":        true,
+	"/manager.device_management/GetManagerFirmwareBanks":     true,
+	"/manager.device_management/GetHostName":                 true,
+	"/manager.device_management/GetDeviceData":               true,
+	"/manager.device_management/DiffDevices":                 true,
+	"/manager.device_management/GetRfAPIList":                true,
+	"/manager.device_management/GetDeviceSupportedResetType": true,
+	"/manager.device_management/GetManagerDateTime":          true,
+	"/manager.device_management/GetManagerCertificate":       true,
+	"/manager.device_management/GetHealthRollup":             true,
+	"/manager.device_management/GetNetworkPortStats":         true,
+	"/manager.device_management/GetThermalRedundancy":        true,
+	"/manager.device_management/GetPowerRedundancy":          true,
+	"/manager.device_management/GetPCIeDevices":              true,
+	"/manager.device_management/GetAcceleratorTelemetry":     true,
+	"/manager.device_management/GetSerialConsoleStatus":      true,
+	"/manager.device_management/CheckRedfishConformance":     true,
+	"/manager.device_management/GetManufacturingInfo":        true,
+	"/manager.device_management/GetPhysicalLocation":         true,
+	"/manager.device_management/GetWatchdog":                 true,
+	"/manager.device_management/GetFanControl":               true,
+	"/manager.device_management/GetEventServiceStatus":       true,
+	"/manager.device_management/GetBootProgress":             true,
+	"/manager.device_management/GetBootPolicy":               true,
+	"/manager.device_management/GetBootOrder":                true,
+	"/manager.device_management/ExportBiosSettings":          true,
+	"/manager.device_management/GetSystemCapacity":           true,
+	"/manager.device_management/GetActionAllowableValues":    true,
+	"/manager.device_management/GetDeviceTemperatures":       true,
+	"/manager.device_management/GetTemperatureThresholds":    true,
+	"/manager.device_management/GetPowerCap":                 true,
+	"/manager.device_management/GetPollStatus":               true,
+	"/manager.device_management/GetPowerHistory":             true,
+}
+
+/* isRetryable() reports whether a failed manager call may succeed when sent again, the manager also returns
+ * the HTTP status of the device as the status code, so 503 and 504 of a busy BMC are retried as well
+ */
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Code(http.StatusServiceUnavailable), codes.Code(http.StatusGatewayTimeout):
+		return true
+	}
+	return false
+}
+
+/* retryInterceptor() sends a read-only unary manager call up to GlobalConfig.RetryAttempts times while it fails
+ * with a retryable status, doubling the wait from GlobalConfig.RetryBackoff milliseconds after each attempt
+ */
+func retryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	managerLock.RLock()
+	backoff := time.Duration(GlobalConfig.RetryBackoff) * time.Millisecond
+	attempts := GlobalConfig.RetryAttempts
+	managerLock.RUnlock()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if !retryableMethods[method] {
+		return err
+	}
+	for attempt := 1; attempt < attempts && isRetryable(err); attempt++ {
+		Warn.Printf("%s failed: %v, retrying in %v", method, err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+		err = invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return err
+}
@@ -0,0 +1,307 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//cronMacros maps the cron shorthands to their five field expressions
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+//cronSchedule holds the minutes, hours, days of month, months and days of week a cron expression matches as bit sets
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+/* parseCronField() returns the bit set of a cron field: "*", a value, a range "a-b" or a list of them, each
+ * optionally stepped by "/n"
+ */
+func parseCronField(field string, min, max int) (bits uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in cron field '%s'", field)
+			}
+			part = part[:i]
+		}
+		low, high := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			low, err = strconv.Atoi(bounds[0])
+			if err == nil {
+				high, err = strconv.Atoi(bounds[1])
+			}
+		default:
+			low, err = strconv.Atoi(part)
+			high = low
+			if err == nil && step != 1 {
+				high = max
+			}
+		}
+		if err != nil || low < min || high > max || low > high {
+			return 0, fmt.Errorf("invalid cron field '%s', expected values %d-%d", field, min, max)
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+/* parseCron() parses a five field cron expression (minute hour day-of-month month day-of-week) or one of the
+ * shorthands like "@daily", a day of week of 7 is Sunday like 0
+ */
+func parseCron(fields []string) (*cronSchedule, error) {
+	if len(fields) == 1 {
+		if expression, ok := cronMacros[fields[0]]; ok {
+			fields = strings.Fields(expression)
+		}
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s', expected minute hour day-of-month month day-of-week", strings.Join(fields, " "))
+	}
+	schedule := &cronSchedule{domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	return schedule, nil
+}
+
+/* matchDay() applies the cron rule for the day fields: when both are restricted a day matching either one runs
+ */
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowMatch
+	case c.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
+
+/* next() returns the first minute after t the schedule matches, the zero time when none falls within five years
+ * (e.g. "0 0 31 2 *")
+ */
+func (c *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+//scheduledCommand is a command line run by demotest on a cron schedule
+type scheduledCommand struct {
+	ID         int
+	Cron       string
+	Command    string
+	schedule   *cronSchedule
+	Next       time.Time
+	LastRun    time.Time
+	LastResult string
+	stop       chan struct{}
+}
+
+var (
+	schedulesLock  sync.Mutex
+	schedules      = map[int]*scheduledCommand{}
+	nextScheduleID = 1
+)
+
+//unschedulableCommands need the client connection, would nest schedules or change the config behind the client
+var unschedulableCommands = map[string]bool{"QUIT": true, "streamdevicelogdata": true, "pulldevicelogdata": true, "schedule": true,
+	"reloadconfig": true}
+
+/* addSchedule() registers the command line following the cron expression in words and starts running it, the
+ * command is checked now and parsed again at every run so group and pattern targets follow the attached devices
+ */
+func addSchedule(words []string) string {
+	cronFields := words
+	if len(words) != 0 && strings.HasPrefix(words[0], "@") {
+		cronFields = words[:1]
+	} else if len(words) >= 5 {
+		cronFields = words[:5]
+	}
+	schedule, err := parseCron(cronFields)
+	if err != nil {
+		return err.Error()
+	}
+	commandWords := words[len(cronFields):]
+	if len(commandWords) == 0 {
+		return "schedule expects a command to run"
+	}
+	cmdstr, _ := splitOutputRedirect(strings.Join(commandWords, " "))
+	cmd, _, err := parseCommandLine(strings.Split(cmdstr, " "))
+	if err != nil {
+		return err.Error()
+	}
+	if findCommandSpec(cmd) == nil || unschedulableCommands[cmd] {
+		return "command '" + commandWords[0] + "' cannot be scheduled"
+	}
+	next := schedule.next(time.Now())
+	if next.IsZero() {
+		return "cron expression '" + strings.Join(cronFields, " ") + "' never matches"
+	}
+	schedulesLock.Lock()
+	scheduled := &scheduledCommand{ID: nextScheduleID, Cron: strings.Join(cronFields, " "), Command: strings.Join(commandWords, " "),
+		schedule: schedule, Next: next, stop: make(chan struct{})}
+	schedules[scheduled.ID] = scheduled
+	nextScheduleID++
+	schedulesLock.Unlock()
+	go runSchedule(scheduled)
+	return fmt.Sprintf("schedule %d: '%s' runs at %s, next %s", scheduled.ID, scheduled.Command, scheduled.Cron, next.Format(time.RFC3339))
+}
+
+/* runSchedule() waits for every planned run time of the schedule and runs its command. The next time is computed
+ * from the planned one, not from when the command finished, so runs do not drift; runs missed while a command was
+ * still running are skipped.
+ */
+func runSchedule(scheduled *scheduledCommand) {
+	schedulesLock.Lock()
+	next := scheduled.Next
+	schedulesLock.Unlock()
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-scheduled.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		result := runCommandLine(scheduled.Command)
+		logrus.WithFields(logrus.Fields{
+			"Schedule": scheduled.ID,
+			"Command":  scheduled.Command,
+		}).Info(result)
+		planned := next
+		next = scheduled.schedule.next(planned)
+		if now := time.Now(); !next.IsZero() && next.Before(now) {
+			logrus.Warnf("schedule %d skipped runs missed while '%s' was running", scheduled.ID, scheduled.Command)
+			next = scheduled.schedule.next(now)
+		}
+		schedulesLock.Lock()
+		scheduled.LastRun = planned
+		scheduled.LastResult = result
+		scheduled.Next = next
+		schedulesLock.Unlock()
+		if next.IsZero() {
+			return
+		}
+	}
+}
+
+//removeSchedule stops the schedule and forgets it
+func removeSchedule(id string) string {
+	scheduleID, err := strconv.Atoi(id)
+	if err != nil {
+		return "invalid schedule id " + id
+	}
+	schedulesLock.Lock()
+	defer schedulesLock.Unlock()
+	scheduled, ok := schedules[scheduleID]
+	if !ok {
+		return "unknown schedule " + id
+	}
+	close(scheduled.stop)
+	delete(schedules, scheduleID)
+	return "schedule " + id + " removed"
+}
+
+//listSchedules shows every schedule with its next run and the result of its last run
+func listSchedules() string {
+	schedulesLock.Lock()
+	defer schedulesLock.Unlock()
+	if len(schedules) == 0 {
+		return "No schedules"
+	}
+	ids := []int{}
+	for id := range schedules {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	lines := []string{}
+	for _, id := range ids {
+		scheduled := schedules[id]
+		next := "none"
+		if !scheduled.Next.IsZero() {
+			next = scheduled.Next.Format(time.RFC3339)
+		}
+		line := fmt.Sprintf("%d [%s] %s next: %s", id, scheduled.Cron, scheduled.Command, next)
+		if !scheduled.LastRun.IsZero() {
+			line = line + fmt.Sprintf(" last: %s %s", scheduled.LastRun.Format(time.RFC3339), strings.Replace(scheduled.LastResult, "\n", " ", -1))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
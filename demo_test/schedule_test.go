@@ -0,0 +1,132 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		field    string
+		min, max int
+		bits     uint64
+		wantErr  bool
+	}{
+		{"*", 0, 5, 0x3f, false},
+		{"3", 0, 59, 1 << 3, false},
+		{"1-3", 0, 59, 1<<1 | 1<<2 | 1<<3, false},
+		{"1,4", 0, 59, 1<<1 | 1<<4, false},
+		{"*/20", 0, 59, 1<<0 | 1<<20 | 1<<40, false},
+		{"10-20/5", 0, 59, 1<<10 | 1<<15 | 1<<20, false},
+		{"50/5", 0, 59, 1<<50 | 1<<55, false},
+		{"60", 0, 59, 0, true},
+		{"0", 1, 31, 0, true},
+		{"5-1", 0, 59, 0, true},
+		{"*/0", 0, 59, 0, true},
+		{"a", 0, 59, 0, true},
+		{"", 0, 59, 0, true},
+	}
+	for _, test := range tests {
+		bits, err := parseCronField(test.field, test.min, test.max)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseCronField(%q) error %v, want error %v", test.field, err, test.wantErr)
+			continue
+		}
+		if bits != test.bits {
+			t.Errorf("parseCronField(%q) = %#x, want %#x", test.field, bits, test.bits)
+		}
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		fields  []string
+		wantErr bool
+	}{
+		{[]string{"@daily"}, false},
+		{[]string{"@hourly"}, false},
+		{[]string{"0", "11", "*", "*", "*"}, false},
+		{[]string{"*/15", "9-17", "*", "*", "1-5"}, false},
+		{[]string{"0", "0", "*", "*", "7"}, false},
+		{[]string{"@never"}, true},
+		{[]string{"0", "11", "*", "*"}, true},
+		{[]string{"0", "24", "*", "*", "*"}, true},
+		{[]string{"0", "0", "0", "*", "*"}, true},
+		{[]string{"0", "0", "*", "13", "*"}, true},
+		{[]string{"0", "0", "*", "*", "8"}, true},
+	}
+	for _, test := range tests {
+		_, err := parseCron(test.fields)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseCron(%q) error %v, want error %v", test.fields, err, test.wantErr)
+		}
+	}
+	schedule, err := parseCron([]string{"0", "0", "*", "*", "7"})
+	if err != nil || schedule.dow&1 == 0 {
+		t.Errorf("parseCron: day of week 7 does not match Sunday")
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	utc := time.UTC
+	tests := []struct {
+		cron []string
+		from time.Time
+		want time.Time
+	}{
+		{[]string{"0", "11", "*", "*", "*"}, time.Date(2026, 10, 16, 10, 15, 0, 0, kolkata), time.Date(2026, 10, 16, 11, 0, 0, 0, kolkata)},
+		{[]string{"0", "11", "*", "*", "*"}, time.Date(2026, 10, 16, 11, 0, 0, 0, kolkata), time.Date(2026, 10, 17, 11, 0, 0, 0, kolkata)},
+		{[]string{"30", "*", "*", "*", "*"}, time.Date(2026, 10, 16, 10, 45, 30, 0, kolkata), time.Date(2026, 10, 16, 11, 30, 0, 0, kolkata)},
+		{[]string{"@daily"}, time.Date(2026, 10, 16, 23, 59, 0, 0, utc), time.Date(2026, 10, 17, 0, 0, 0, 0, utc)},
+		{[]string{"@hourly"}, time.Date(2026, 10, 16, 10, 0, 59, 0, utc), time.Date(2026, 10, 16, 11, 0, 0, 0, utc)},
+		{[]string{"*/15", "9-17", "*", "*", "1-5"}, time.Date(2026, 10, 16, 17, 50, 0, 0, utc), time.Date(2026, 10, 19, 9, 0, 0, 0, utc)},
+		{[]string{"0", "0", "29", "2", "*"}, time.Date(2026, 3, 1, 0, 0, 0, 0, utc), time.Date(2028, 2, 29, 0, 0, 0, 0, utc)},
+		{[]string{"0", "0", "13", "*", "5"}, time.Date(2026, 10, 10, 0, 0, 0, 0, utc), time.Date(2026, 10, 13, 0, 0, 0, 0, utc)},
+		{[]string{"0", "0", "31", "2", "*"}, time.Date(2026, 1, 1, 0, 0, 0, 0, utc), time.Time{}},
+	}
+	for _, test := range tests {
+		schedule, err := parseCron(test.cron)
+		if err != nil {
+			t.Fatalf("parseCron(%q): %v", test.cron, err)
+		}
+		if got := schedule.next(test.from); !got.Equal(test.want) {
+			t.Errorf("next(%q, %v) = %v, want %v", test.cron, test.from, got, test.want)
+		}
+	}
+}
+
+func TestAddScheduleRejectsCommands(t *testing.T) {
+	for _, line := range []string{"reloadconfig /tmp/demotest.yaml", "QUIT", "schedule @daily listcommands"} {
+		words := append([]string{"@daily"}, strings.Split(line, " ")...)
+		want := "command '" + strings.Split(line, " ")[0] + "' cannot be scheduled"
+		if got := addSchedule(words); got != want {
+			t.Errorf("addSchedule(%q) = %q, want %q", words, got, want)
+		}
+	}
+	if len(schedules) != 0 {
+		t.Errorf("schedules = %v, want none", schedules)
+	}
+}
@@ -23,15 +23,24 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"crypto/tls"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	manager "devicemanager/demo_test/proto"
 
@@ -60,968 +69,2749 @@ func GetCurrentDevices() ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	return retMsg.IpAddress, err
+	devices := []string{}
+	for _, ipAddress := range retMsg.IpAddress {
+		if retMsg.State[ipAddress] == "DEGRADED" {
+			ipAddress = ipAddress + "(DEGRADED)"
+		}
+		devices = append(devices, ipAddress)
+	}
+	return devices, err
 }
 
-func topicListener(topic *string, master sarama.Consumer) {
-	logrus.Info("Starting topicListener for ", *topic)
-	consumer, err := master.ConsumePartition(*topic, 0, sarama.OffsetOldest)
+//findPollingRfAPI lists the attached devices polling the Redfish API and the devices which do not
+func findPollingRfAPI(token, rfAPI string) string {
+	deviceList, err := cc.GetCurrentDevices(ctx, new(manager.Empty))
 	if err != nil {
-		logrus.Errorf("topicListener panic, topic=[%s]: %s", *topic, err.Error())
-		os.Exit(1)
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
+		return errStatus.Message()
 	}
-	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, os.Interrupt)
-	doneCh := make(chan struct{})
-	go func() {
-		for {
-			select {
-			case err := <-consumer.Errors():
-				logrus.Errorf("Consumer error: %s", err.Err)
-			case msg := <-consumer.Messages():
-				logrus.Infof("Got message on topic=[%s]: %s", *topic, string(msg.Value))
-			case <-signals:
-				logrus.Warn("Interrupt is detected")
-				os.Exit(1)
+	rfAPI = strings.TrimSuffix(rfAPI, "/")
+	polling, missing := []string{}, []string{}
+	for _, ipAddress := range deviceList.IpAddress {
+		rfList := new(manager.Device)
+		rfList.IpAddress = ipAddress
+		rfList.UserOrToken = token
+		retMsg, err := cc.GetRfAPIList(ctx, rfList)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			logrus.Errorf("list polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			missing = append(missing, ipAddress+" ("+errStatus.Message()+")")
+			continue
+		}
+		found := false
+		for _, api := range retMsg.RfAPIList {
+			if strings.TrimSuffix(api, "/") == rfAPI {
+				found = true
+				break
 			}
 		}
-	}()
-	<-doneCh
+		if found {
+			polling = append(polling, ipAddress)
+		} else {
+			missing = append(missing, ipAddress)
+		}
+	}
+	sort.Strings(polling)
+	sort.Strings(missing)
+	return "Polling " + rfAPI + ": " + strings.Join(polling, " ") + "\nNot polling: " + strings.Join(missing, " ")
 }
 
-func kafkainit() {
-	var kafkaIP string
-	if GlobalConfig.Kafka == "kafka_ip.sh" {
-		kafkaIP = runCommand(GlobalConfig.Kafka) + ":9092"
-		logrus.Info("IP address of kafka-cord-0: ", kafkaIP)
-	} else {
-		kafkaIP = GlobalConfig.Kafka
+//formatAccountPolicy shows the password length and lockout policies of the device on one line
+func formatAccountPolicy(ipAddress string, policy *manager.AccountServicePolicy) string {
+	line := fmt.Sprintf("%s password length: %d-%d lockout threshold: %d lockout duration: %ds counter reset after: %ds",
+		ipAddress, policy.MinPasswordLength, policy.MaxPasswordLength, policy.AccountLockoutThreshold,
+		policy.AccountLockoutDuration, policy.AccountLockoutCounterResetAfter)
+	if len(policy.NotReported) != 0 {
+		line = line + " (not reported: " + strings.Join(policy.NotReported, " ") + ")"
 	}
+	return line
+}
 
-	config := sarama.NewConfig()
-	config.Consumer.Return.Errors = true
-	master, err := sarama.NewConsumer([]string{kafkaIP}, config)
+/* createAccountsFromFile() creates the accounts listed in a CSV file of ip,port,token,username,password,privilege
+ * rows, a header row and lines starting with '#' are ignored and a malformed row is skipped, the result of every
+ * row is reported with its row number. Spaces around the fields are trimmed except in the password, which is used
+ * as it is written
+ */
+func createAccountsFromFile(file string) string {
+	csvFile, err := os.Open(file)
 	if err != nil {
-		panic(err)
+		return "read accounts file error: " + err.Error()
+	}
+	defer csvFile.Close()
+	reader := csv.NewReader(csvFile)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	results := []string{}
+	created, failed, skipped := 0, 0, 0
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); !ok {
+				results = append(results, "read accounts file error: "+err.Error())
+				break
+			}
+			results = append(results, fmt.Sprintf("row %d: skipped, %v", row, err))
+			skipped++
+			continue
+		}
+		if row == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "ip") {
+			continue
+		}
+		for i := range record {
+			if i != 4 {
+				record[i] = strings.TrimSpace(record[i])
+			}
+		}
+		if len(record) != 6 || record[0] == "" || record[1] == "" || record[3] == "" {
+			results = append(results, fmt.Sprintf("row %d: skipped, expected ip,port,token,username,password,privilege", row))
+			skipped++
+			continue
+		}
+		deviceAccount := new(manager.DeviceAccount)
+		deviceAccount.IpAddress = record[0] + ":" + record[1]
+		deviceAccount.UserOrToken = record[2]
+		deviceAccount.ActUsername = record[3]
+		deviceAccount.ActPassword = record[4]
+		deviceAccount.Privilege = record[5]
+		if _, err := cc.CreateDeviceAccount(ctx, deviceAccount); err != nil {
+			errStatus, _ := status.FromError(err)
+			logrus.Errorf("create user account error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			results = append(results, fmt.Sprintf("row %d: %s %s failed, %s", row, deviceAccount.IpAddress, deviceAccount.ActUsername, errStatus.Message()))
+			failed++
+			continue
+		}
+		results = append(results, fmt.Sprintf("row %d: %s %s created", row, deviceAccount.IpAddress, deviceAccount.ActUsername))
+		created++
 	}
-	DataConsumer = master
+	results = append(results, fmt.Sprintf("%d created, %d failed, %d skipped", created, failed, skipped))
+	return strings.Join(results, "\n")
+}
 
-	go topicListener(&GlobalConfig.Topic, master)
+//biosSettingsFile is the layout of the files written by exportbios and read by importbios
+type biosSettingsFile struct {
+	AttributeRegistry string                 `json:"AttributeRegistry,omitempty"`
+	Attributes        map[string]interface{} `json:"Attributes"`
 }
 
-func main() {
-	ParseCommandLine()
-	ProcessGlobalOptions()
-	ShowGlobalOptions()
+//exportBios writes the Bios attributes of the device to a new JSON file below the output directory of demotest
+func exportBios(ipAddress, token, file string) string {
+	biosData := &manager.BiosSettings{IpAddress: ipAddress, UserOrToken: token}
+	retMsg, err := cc.ExportBiosSettings(ctx, biosData)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("export Bios settings error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		return errStatus.Message()
+	}
+	settings := biosSettingsFile{AttributeRegistry: retMsg.AttributeRegistry}
+	if err := json.Unmarshal([]byte(retMsg.Attributes), &settings.Attributes); err != nil {
+		return "invalid Bios attributes: " + err.Error()
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return "invalid Bios attributes: " + err.Error()
+	}
+	outFile, err := createOutputFile(file)
+	if err != nil {
+		return "failed to write output: " + err.Error()
+	}
+	_, err = outFile.Write(append(data, '\n'))
+	if closeErr := outFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "failed to write output: " + err.Error()
+	}
+	return fmt.Sprintf("%s %d Bios attributes written to %s", ipAddress, len(settings.Attributes), outFile.Name())
+}
 
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	logrus.Info("Launching server...")
-	if GlobalConfig.Consumer {
-		logrus.Info("kafkaInit starting")
-		kafkainit()
+/* importBios() stages the Bios attributes of a file written by exportbios, or of a plain JSON object of attributes,
+ * below the output directory on the device and reports the staged, unchanged and rejected attributes
+ */
+func importBios(ipAddress, token, file string) string {
+	filePath, err := outputFilePath(file)
+	if err != nil {
+		return "read Bios settings error: " + err.Error()
 	}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return "read Bios settings error: " + err.Error()
+	}
+	settings := biosSettingsFile{}
+	if err := json.Unmarshal(data, &settings); err != nil || settings.Attributes == nil {
+		settings = biosSettingsFile{}
+		if err := json.Unmarshal(data, &settings.Attributes); err != nil {
+			return "invalid Bios settings file " + file + ": " + err.Error()
+		}
+	}
+	attributes, _ := json.Marshal(settings.Attributes)
+	biosData := &manager.BiosSettings{IpAddress: ipAddress, UserOrToken: token, Attributes: string(attributes)}
+	retMsg, err := cc.ImportBiosSettings(ctx, biosData)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("import Bios settings error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		return errStatus.Message()
+	}
+	result := fmt.Sprintf("%s %d Bios attributes staged at %s (applied at the next reset), %d unchanged, %d rejected",
+		ipAddress, len(retMsg.Staged), retMsg.SettingsOdataId, retMsg.Unchanged, len(retMsg.Rejected))
+	if settings.AttributeRegistry != "" && settings.AttributeRegistry != retMsg.AttributeRegistry {
+		result = result + "\n\tattribute registry " + retMsg.AttributeRegistry + " differs from " + settings.AttributeRegistry + " of " + file
+	}
+	for _, rejected := range retMsg.Rejected {
+		result = result + "\n\t" + rejected
+	}
+	return result
+}
 
-	ln, err := net.Listen("tcp", GlobalConfig.Local)
+/* normalizeDeviceAddress() returns the canonical form of an attached ip address:port, leading zeros of the IPv4
+ * octets and of the port are dropped and a host name is lower cased
+ */
+func normalizeDeviceAddress(ipAddress string) string {
+	i := strings.LastIndex(ipAddress, ":")
+	if i < 0 {
+		return strings.ToLower(ipAddress)
+	}
+	host, port := ipAddress[:i], ipAddress[i+1:]
+	if n, err := strconv.Atoi(port); err == nil {
+		port = strconv.Itoa(n)
+	}
+	octets := strings.Split(host, ".")
+	numeric := len(octets) == 4
+	for index, octet := range octets {
+		n, err := strconv.Atoi(octet)
+		if err != nil || n < 0 || n > 255 {
+			numeric = false
+			break
+		}
+		octets[index] = strconv.Itoa(n)
+	}
+	if numeric {
+		host = strings.Join(octets, ".")
+	} else if ip := net.ParseIP(host); ip != nil {
+		host = ip.String()
+	} else {
+		host = strings.ToLower(host)
+	}
+	return host + ":" + port
+}
+
+/* findDuplicateDevices() reports the attached devices registered more than once under different ip address:port
+ * forms, every group is keyed by the normalized address
+ */
+func findDuplicateDevices() string {
+	deviceList, err := cc.GetCurrentDevices(ctx, new(manager.Empty))
 	if err != nil {
-		fmt.Println("could not listen")
-		logrus.Fatalf("did not listen: %v", err)
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
+		return errStatus.Message()
 	}
-	defer ln.Close()
+	registrations := map[string][]string{}
+	for _, ipAddress := range deviceList.IpAddress {
+		key := normalizeDeviceAddress(ipAddress)
+		registrations[key] = append(registrations[key], ipAddress)
+	}
+	duplicates := []string{}
+	for key, ipAddresses := range registrations {
+		if len(ipAddresses) > 1 {
+			sort.Strings(ipAddresses)
+			duplicates = append(duplicates, key+": "+strings.Join(ipAddresses, " "))
+		}
+	}
+	if len(duplicates) == 0 {
+		return fmt.Sprintf("No duplicate registrations among %d devices", len(deviceList.IpAddress))
+	}
+	sort.Strings(duplicates)
+	return strings.Join(duplicates, "\n")
+}
 
-	conn, err = grpc.Dial(GlobalConfig.Manager, grpc.WithInsecure())
+//periodAll sets the polling frequency of every attached device and reports how many succeeded
+func periodAll(token, frequency string) string {
+	u, err := strconv.ParseUint(frequency, 10, 32)
 	if err != nil {
-		logrus.Fatalf("did not connect: %v", err)
+		return "invalid frequency " + frequency
+	}
+	deviceList, err := cc.GetCurrentDevices(ctx, new(manager.Empty))
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
+		return errStatus.Message()
+	}
+	failed := []string{}
+	for _, ipAddress := range deviceList.IpAddress {
+		freqinfo := new(manager.Device)
+		freqinfo.Frequency = uint32(u)
+		freqinfo.IpAddress = ipAddress
+		freqinfo.UserOrToken = token
+		if _, err := cc.SetFrequency(ctx, freqinfo); err != nil {
+			errStatus, _ := status.FromError(err)
+			logrus.Errorf("period error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			failed = append(failed, ipAddress+" ("+errStatus.Message()+")")
+		}
 	}
-	defer conn.Close()
+	newmessage := fmt.Sprintf("Period set to %d on %d of %d devices", u, len(deviceList.IpAddress)-len(failed), len(deviceList.IpAddress))
+	if len(failed) != 0 {
+		sort.Strings(failed)
+		newmessage = newmessage + "\nFailed: " + strings.Join(failed, " ")
+	}
+	return newmessage
+}
 
-	cc = manager.NewDeviceManagementClient(conn)
-	ctx = context.Background()
+//worstHealth returns the most severe health rollup of the resources
+func worstHealth(resources []*manager.ResourceHealth) string {
+	severity := map[string]int{"OK": 1, "Warning": 2, "Critical": 3}
+	worst := "Unknown"
+	for _, resource := range resources {
+		if severity[resource.HealthRollup] > severity[worst] {
+			worst = resource.HealthRollup
+		}
+	}
+	return worst
+}
 
-	loop := true
+//healthAllWorkers bounds the number of concurrent health rollup requests of healthall
+const healthAllWorkers = 8
 
-	for loop {
-		connS, err := ln.Accept()
+//healthAll gets the health rollup of every attached device and summarizes the devices by their worst health
+func healthAll(token string) string {
+	deviceList, err := cc.GetCurrentDevices(ctx, new(manager.Empty))
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
+		return errStatus.Message()
+	}
+	healths := make([]string, len(deviceList.IpAddress))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < healthAllWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				healthRollup := new(manager.HealthRollup)
+				healthRollup.IpAddress = deviceList.IpAddress[i]
+				healthRollup.UserOrToken = token
+				retMsg, err := cc.GetHealthRollup(ctx, healthRollup)
+				if err != nil {
+					errStatus, _ := status.FromError(err)
+					logrus.Errorf("get health rollup error - status code %v message %v", errStatus.Code(), errStatus.Message())
+					healths[i] = "Unknown"
+					continue
+				}
+				healths[i] = worstHealth(retMsg.Resources)
+			}
+		}()
+	}
+	for i := range deviceList.IpAddress {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	counts := map[string]int{}
+	notOK := []string{}
+	for i, health := range healths {
+		counts[health]++
+		if health != "OK" {
+			notOK = append(notOK, deviceList.IpAddress[i]+" "+health)
+		}
+	}
+	sort.Strings(notOK)
+	summary := "OK: " + strconv.Itoa(counts["OK"]) + " Warning: " + strconv.Itoa(counts["Warning"]) + " Critical: " + strconv.Itoa(counts["Critical"])
+	if counts["Unknown"] != 0 {
+		summary = summary + " Unknown: " + strconv.Itoa(counts["Unknown"])
+	}
+	if len(notOK) != 0 {
+		summary = summary + "\n" + strings.Join(notOK, "\n")
+	}
+	return summary
+}
+
+//pollCheckDefaultMaxAge is the age in seconds after which pollcheck reports a device's last successful poll as stale
+const pollCheckDefaultMaxAge = 300
+
+/* pollCheck reads the poll status of every attached device and lists the devices whose last successful poll is older
+ * than maxAge seconds, the devices never polled successfully and the devices whose poll status could not be read
+ */
+func pollCheck(token, maxAge string) string {
+	threshold := int64(pollCheckDefaultMaxAge)
+	if maxAge != "" {
+		u, err := strconv.ParseUint(maxAge, 10, 32)
+		if err != nil || u == 0 {
+			return "invalid freshness threshold " + maxAge
+		}
+		threshold = int64(u)
+	}
+	deviceList, err := cc.GetCurrentDevices(ctx, new(manager.Empty))
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
+		return errStatus.Message()
+	}
+	now := time.Now().Unix()
+	stale, never, failed := []string{}, []string{}, []string{}
+	for _, ipAddress := range deviceList.IpAddress {
+		pollStatus := new(manager.PollStatus)
+		pollStatus.IpAddress = ipAddress
+		pollStatus.UserOrToken = token
+		retMsg, err := cc.GetPollStatus(ctx, pollStatus)
 		if err != nil {
-			logrus.Fatalf("Accept error: %v", err)
+			errStatus, _ := status.FromError(err)
+			logrus.Errorf("get poll status error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			failed = append(failed, ipAddress+" ("+errStatus.Message()+")")
+			continue
 		}
-		cmdstr, _ := bufio.NewReader(connS).ReadString('\n')
-		cmdstr = strings.TrimSuffix(cmdstr, "\n")
-		s := strings.Split(cmdstr, " ")
-		newmessage := ""
-		cmd := string(s[0])
+		if retMsg.LastSuccess == 0 {
+			never = append(never, ipAddress+" last error: "+retMsg.LastError)
+			continue
+		}
+		if age := now - retMsg.LastSuccess; age > threshold {
+			stale = append(stale, fmt.Sprintf("%s last success %ds ago (%s)", ipAddress, age, time.Unix(retMsg.LastSuccess, 0).UTC().Format(time.RFC3339)))
+		}
+	}
+	fresh := len(deviceList.IpAddress) - len(stale) - len(never) - len(failed)
+	newmessage := fmt.Sprintf("Fresh: %d Stale: %d Never polled: %d Unknown: %d (threshold %ds)", fresh, len(stale), len(never), len(failed), threshold)
+	sort.Strings(stale)
+	for _, device := range stale {
+		newmessage = newmessage + "\nStale: " + device
+	}
+	sort.Strings(never)
+	for _, device := range never {
+		newmessage = newmessage + "\nNever polled: " + device
+	}
+	sort.Strings(failed)
+	for _, device := range failed {
+		newmessage = newmessage + "\nUnknown: " + device
+	}
+	return newmessage
+}
 
-		switch cmd {
-		case "attach":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
+//fleetGetWorkers bounds the number of concurrent Redfish reads of fleetget
+const fleetGetWorkers = 8
+
+/* fleetGet reads the Redfish API from every attached device and returns a JSON object mapping each device to the
+ * resource, or to {"error": message} when the read failed
+ */
+func fleetGet(token, rfAPI string) string {
+	deviceList, err := cc.GetCurrentDevices(ctx, new(manager.Empty))
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
+		return errStatus.Message()
+	}
+	resources := make(map[string]json.RawMessage, len(deviceList.IpAddress))
+	var resourcesLock sync.Mutex
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for w := 0; w < fleetGetWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ipAddress := range jobs {
+				deviceinfo := new(manager.Device)
+				deviceinfo.IpAddress = ipAddress
+				deviceinfo.UserOrToken = token
+				deviceinfo.RedfishAPI = rfAPI
+				deviceinfo.HttpInfo = &manager.HttpInfo{HttpMethod: "GET"}
+				var resource json.RawMessage
+				retMsg, err := cc.GenericDeviceAccess(ctx, deviceinfo)
+				if err != nil {
+					errStatus, _ := status.FromError(err)
+					logrus.Errorf("fleet get error - status code %v message %v", errStatus.Code(), errStatus.Message())
+					resource, _ = json.Marshal(map[string]string{"error": errStatus.Message()})
+				} else if json.Valid([]byte(retMsg.ResultData)) {
+					resource = json.RawMessage(retMsg.ResultData)
+				} else {
+					resource, _ = json.Marshal(retMsg.ResultData)
+				}
+				resourcesLock.Lock()
+				resources[ipAddress] = resource
+				resourcesLock.Unlock()
+			}
+		}()
+	}
+	for _, ipAddress := range deviceList.IpAddress {
+		jobs <- ipAddress
+	}
+	close(jobs)
+	wg.Wait()
+	data, err := json.MarshalIndent(resources, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+//pushSoftwareImage streams a local software image file to the manager which pushes it to the device
+func pushSoftwareImage(ipAddress, token, filePath, applyTime string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	stream, err := cc.PushDeviceSoftware(ctx)
+	if err != nil {
+		return "", err
+	}
+	chunk := &manager.SoftwareImageChunk{IpAddress: ipAddress, UserOrToken: token, FileName: filepath.Base(filePath), ApplyTime: applyTime}
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
 				break
 			}
-			var devicelist manager.DeviceList
-			var ipattached []string
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 5 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				deviceinfo := new(manager.DeviceInfo)
-				deviceinfo.IpAddress = info[0] + ":" + info[1]
-				freq, err := strconv.ParseUint(info[2], 10, 32)
-				deviceinfo.DetectDevice, _ = strconv.ParseBool(info[3])
-				deviceinfo.PassAuth, _ = strconv.ParseBool(info[4])
+			chunk = &manager.SoftwareImageChunk{}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			stream.CloseSend()
+			return "", err
+		}
+	}
+	task, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", err
+	}
+	return task.TaskURI, nil
+}
+
+//Kafka consumer reconnection backoff bounds
+const (
+	kafkaRetryMinBackoff = time.Second
+	kafkaRetryMaxBackoff = time.Minute
+)
+
+/* consumeTopic() logs the messages of the topic from offset until the partition consumer fails or ctx is
+ * cancelled, it returns the offset to resume from
+ */
+func consumeTopic(ctx context.Context, topic string, master sarama.Consumer, offset int64) (int64, error) {
+	consumer, err := master.ConsumePartition(topic, 0, offset)
+	if err != nil {
+		return offset, err
+	}
+	defer consumer.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return offset, ctx.Err()
+		case err := <-consumer.Errors():
+			return offset, err.Err
+		case msg, ok := <-consumer.Messages():
+			if !ok {
+				return offset, errors.New("partition consumer closed")
+			}
+			logrus.Infof("Got message on topic=[%s]: %s", topic, string(msg.Value))
+			offset = msg.Offset + 1
+		}
+	}
+}
+
+/* topicListener() consumes the topic and recreates the consumer with an exponential backoff whenever it fails,
+ * e.g. while the brokers restart, resuming after the last message received, until ctx is cancelled
+ */
+func topicListener(ctx context.Context, topic string, config *sarama.Config) {
+	logrus.Info("Starting topicListener for ", topic)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		select {
+		case <-signals:
+			logrus.Warn("Interrupt is detected")
+			os.Exit(1)
+		case <-ctx.Done():
+			signal.Stop(signals)
+		}
+	}()
+	offset := sarama.OffsetOldest
+	backoff := kafkaRetryMinBackoff
+	for ctx.Err() == nil {
+		master, err := sarama.NewConsumer([]string{kafkaAddress()}, config)
+		if err == nil {
+			DataConsumer = master
+			var next int64
+			next, err = consumeTopic(ctx, topic, master, offset)
+			master.Close()
+			if next != offset {
+				backoff = kafkaRetryMinBackoff
+			}
+			offset = next
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		logrus.Errorf("Consumer error, topic=[%s]: %s, reconnecting in %v", topic, err, backoff)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > kafkaRetryMaxBackoff {
+			backoff = kafkaRetryMaxBackoff
+		}
+	}
+	logrus.Info("Stopped topicListener for ", topic)
+}
+
+func kafkainit() {
+	if err := startKafka(); err != nil {
+		logrus.Fatalf("Kafka init error: %v", err)
+	}
+}
+
+/* streamDeviceLogData() writes the log entries added since the last poll to the client every interval,
+ * until the client disconnects. The stream id makes the manager keep a log cursor of its own for this stream
+ */
+func streamDeviceLogData(connS net.Conn, deviceLogService *manager.LogService, interval time.Duration) {
+	defer connS.Close()
+	hostName, _ := os.Hostname()
+	deviceLogService.StreamId = fmt.Sprintf("%s/%s/%d", hostName, connS.RemoteAddr(), time.Now().UnixNano())
+	disconnected := make(chan bool)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			if _, err := connS.Read(buf); err != nil {
+				close(disconnected)
+				return
+			}
+		}
+	}()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		//the manager is acquired again at every poll so the stream follows a reloaded manager address
+		client, managerCtx, release := acquireManager()
+		retMsg, err := client.GetNewDeviceLogData(managerCtx, deviceLogService)
+		release()
+		newmessage := ""
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message() + "\n"
+			logrus.Errorf("stream device log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else if len(retMsg.LogData) != 0 {
+			newmessage = strings.Join(retMsg.LogData, "\n") + "\n"
+		}
+		if newmessage != "" {
+			if n, err := connS.Write([]byte(newmessage)); err != nil {
+				logrus.Errorf("err writing to client:%s, n:%d", err, n)
+				return
+			}
+		}
+		select {
+		case <-disconnected:
+			logrus.Info("stream device log data client disconnected ", deviceLogService.IpAddress)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/* pullDeviceLogData() consumes StreamDeviceLogData and writes every batch of log entries to the client as it
+ * arrives, or appends it to outputFile below the output directory when one is given, it returns the summary to send
+ * at the end
+ */
+func pullDeviceLogData(connS net.Conn, deviceLogService *manager.LogService, outputFile string) string {
+	var out io.Writer = connS
+	if outputFile != "" {
+		file, err := createOutputFile(outputFile)
+		if err != nil {
+			return "failed to write output: " + err.Error()
+		}
+		defer file.Close()
+		out = file
+	}
+	client, managerCtx, release := acquireManager()
+	defer release()
+	stream, err := client.StreamDeviceLogData(managerCtx, deviceLogService)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("pull device log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		return errStatus.Message()
+	}
+	entries := 0
+	for {
+		retMsg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			logrus.Errorf("pull device log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			return fmt.Sprintf("%s (after %d entries)", errStatus.Message(), entries)
+		}
+		if _, err := out.Write([]byte(strings.Join(retMsg.LogData, "\n") + "\n")); err != nil {
+			logrus.Errorf("err writing log entries:%s", err)
+			return "failed to write output: " + err.Error()
+		}
+		entries += len(retMsg.LogData)
+	}
+	if outputFile != "" {
+		return fmt.Sprintf("%d log entries written to %s", entries, out.(*os.File).Name())
+	}
+	return fmt.Sprintf("%d log entries", entries)
+}
+
+/* runGroupCommand() runs the command in line against every member of group and returns the results keyed by
+ * member ip address:port, a failing member does not stop the remaining ones
+ */
+func runGroupCommand(group string, line []string) string {
+	words := []string{}
+	for _, word := range line {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return "rungroup expects a command to run"
+	}
+	cmd, err := resolveCommand(words[0])
+	if err != nil {
+		return err.Error()
+	}
+	spec := findCommandSpec(cmd)
+	if spec == nil || !strings.HasPrefix(spec.layout, "ip:port") || cmd == "streamdevicelogdata" || cmd == "pulldevicelogdata" {
+		return "command '" + words[0] + "' cannot be run against a group"
+	}
+	groupList, err := cc.ListGroups(ctx, new(manager.Empty))
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.Errorf("list groups error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		return errStatus.Message()
+	}
+	var members []string
+	found := false
+	for _, g := range groupList.Group {
+		if g.Name == group {
+			members = g.IpAddress
+			found = true
+		}
+	}
+	if !found {
+		return "unknown group '" + group + "'"
+	}
+	results := []string{}
+	for _, member := range members {
+		args := []string{}
+		for _, arg := range words[1:] {
+			args = append(args, member+":"+arg)
+		}
+		if len(args) == 0 {
+			args = append(args, member)
+		}
+		result := ""
+		targets, err := parseCommandArgs(cmd, args)
+		if err != nil {
+			result = err.Error()
+		} else {
+			result = handleCommand(cmd, targets, cmd+" "+strings.Join(args, " "))
+		}
+		results = append(results, member+": "+result)
+	}
+	return strings.Join(results, "\n")
+}
+
+/* dispatchCommand() runs a validated command which does not need the client connection, the commands taking
+ * the rest of the command line as words are handled here
+ */
+func dispatchCommand(cmd string, targets [][]string, words []string, cmdstr string) string {
+	switch cmd {
+	case "rungroup":
+		return runGroupCommand(targets[0][0], words[2:])
+	case "publishtestevent":
+		message := strings.TrimSpace(strings.Join(append([]string{targets[0][1]}, words[2:]...), " "))
+		return publishTestEvent(targets[0][0], message)
+	case "schedule":
+		return addSchedule(words[1:])
+	}
+	return handleCommand(cmd, targets, cmdstr)
+}
+
+//dispatchLock serializes the commands of the clients and of the schedules, which run on goroutines of their own
+var dispatchLock sync.Mutex
+
+//runCommandLine runs the command line of a schedule and returns its result, or writes it to the "> file" of the line
+func runCommandLine(cmdstr string) string {
+	cmdstr, outputFile := splitOutputRedirect(cmdstr)
+	words := strings.Split(cmdstr, " ")
+	dispatchLock.Lock()
+	cmd, targets, err := parseCommandLine(words)
+	if err != nil {
+		dispatchLock.Unlock()
+		return err.Error()
+	}
+	result := dispatchCommand(cmd, targets, words, cmdstr)
+	dispatchLock.Unlock()
+	if outputFile != "" {
+		result = writeCommandOutput(outputFile, result)
+	}
+	return result
+}
+
+//joinCertificateKey appends the PEM private key to the PEM certificate, on a line of its own
+func joinCertificateKey(certificate, privateKey []byte) string {
+	if len(certificate) != 0 && !bytes.HasSuffix(certificate, []byte("\n")) {
+		return string(certificate) + "\n" + string(privateKey)
+	}
+	return string(certificate) + string(privateKey)
+}
+
+/* handleCommand() runs a validated command, except QUIT, streamdevicelogdata and pulldevicelogdata which need the client connection,
+ * and returns the message to send back to the client
+ */
+func handleCommand(cmd string, targets [][]string, cmdstr string) string {
+	newmessage := ""
+	switch cmd {
+	case "attach":
+		var devicelist manager.DeviceList
+		var ipattached []string
+		for _, info := range targets {
+			deviceinfo := new(manager.DeviceInfo)
+			deviceinfo.IpAddress = info[0] + ":" + info[1]
+			freq, err := strconv.ParseUint(info[2], 10, 32)
+			deviceinfo.DetectDevice, _ = strconv.ParseBool(info[3])
+			deviceinfo.PassAuth, _ = strconv.ParseBool(info[4])
+			if len(info) == 6 {
+				deviceinfo.SkipTLSVerify, _ = strconv.ParseBool(info[5])
+			}
+			if err != nil {
+				newmessage = newmessage + "invalid period " + strings.Join(info, ":")
+				continue
+			}
+			deviceinfo.Frequency = uint32(freq)
+			devicelist.Device = append(devicelist.Device, deviceinfo)
+			ipattached = append(ipattached, deviceinfo.IpAddress)
+		}
+		if len(devicelist.Device) == 0 {
+			break
+		}
+		_, err := cc.SendDeviceList(ctx, &devicelist)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = newmessage + errStatus.Message()
+			logrus.Errorf("attach error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			ips := strings.Join(ipattached, " ")
+			newmessage = newmessage + ips + " attached"
+		}
+	case "detach":
+		for _, args := range targets {
+			device := new(manager.Device)
+			device.IpAddress = args[0] + ":" + args[1]
+			device.UserOrToken = args[2]
+			_, err := cc.DeleteDeviceList(ctx, device)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("detach error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + device.IpAddress + " detached\n"
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "decommission":
+		for _, args := range targets {
+			device := new(manager.Device)
+			device.IpAddress = args[0] + ":" + args[1]
+			device.UserOrToken = args[2]
+			if _, err := cc.StopQueryDeviceData(ctx, device); err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + device.IpAddress + " stop polling: " + errStatus.Message() + "\n"
+				logrus.Errorf("decommission stop error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + device.IpAddress + " stop polling: ok\n"
+			}
+			syslogTarget := new(manager.SyslogTarget)
+			syslogTarget.IpAddress = device.IpAddress
+			syslogTarget.UserOrToken = device.UserOrToken
+			retMsg, err := cc.ClearSyslogTargets(ctx, syslogTarget)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + device.IpAddress + " unsubscribe: " + errStatus.Message() + ", not detached\n"
+				logrus.Errorf("decommission unsubscribe error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + fmt.Sprintf("%s unsubscribe: %d subscriptions deleted\n", device.IpAddress, retMsg.Unsubscribed)
+			if _, err := cc.DeleteDeviceList(ctx, device); err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + device.IpAddress + " detach: " + errStatus.Message() + "\n"
+				logrus.Errorf("decommission detach error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + device.IpAddress + " detach: detached\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "period":
+		args := targets[0]
+		ip := args[0] + ":" + args[1]
+		token := args[2]
+		pv := args[3]
+		u, err := strconv.ParseUint(pv, 10, 64)
+		if err != nil {
+			logrus.Error("ParseUint error!!")
+		} else {
+			freqinfo := new(manager.Device)
+			freqinfo.Frequency = uint32(u)
+			freqinfo.IpAddress = ip
+			freqinfo.UserOrToken = token
+			_, err := cc.SetFrequency(ctx, freqinfo)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("period error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage
+			}
+		}
+	case "showdevices":
+		currentlist, err := GetCurrentDevices()
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
+			newmessage = errStatus.Message()
+			logrus.Info("showdevices error!!")
+		} else {
+			logrus.Info("showdevices ", currentlist)
+			newmessage = strings.Join(currentlist[:], " ")
+		}
+	case "creategroup":
+		for _, info := range targets {
+			group := new(manager.DeviceGroup)
+			group.Name = info[0]
+			_, err := cc.CreateGroup(ctx, group)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("create group error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + group.Name + " created"
+			}
+		}
+	case "addtogroup":
+		for _, info := range targets {
+			group := new(manager.DeviceGroup)
+			group.Name = info[0]
+			group.IpAddress = []string{info[1] + ":" + info[2]}
+			_, err := cc.AddDeviceToGroup(ctx, group)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("add device to group error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + group.IpAddress[0] + " added to " + group.Name
+			}
+		}
+	case "listgroups":
+		groupList, err := cc.ListGroups(ctx, new(manager.Empty))
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("list groups error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			groups := []string{}
+			for _, group := range groupList.Group {
+				groups = append(groups, group.Name+": "+strings.Join(group.IpAddress, " "))
+			}
+			sort.Strings(groups)
+			newmessage = strings.Join(groups, "\n")
+		}
+	case "createaccount":
+		for _, info := range targets {
+			deviceAccount := new(manager.DeviceAccount)
+			deviceAccount.IpAddress = info[0] + ":" + info[1]
+			deviceAccount.UserOrToken = info[2]
+			deviceAccount.ActUsername = info[3]
+			deviceAccount.ActPassword = info[4]
+			deviceAccount.Privilege = info[5]
+			_, err := cc.CreateDeviceAccount(ctx, deviceAccount)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("create user account error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + deviceAccount.ActUsername + " created"
+			}
+		}
+	case "createaccountsfromfile":
+		newmessage = createAccountsFromFile(targets[0][0])
+	case "deleteaccount":
+		for _, info := range targets {
+			deviceAccount := new(manager.DeviceAccount)
+			deviceAccount.IpAddress = info[0] + ":" + info[1]
+			deviceAccount.UserOrToken = info[2]
+			deviceAccount.ActUsername = info[3]
+			_, err := cc.RemoveDeviceAccount(ctx, deviceAccount)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("delete user account error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + deviceAccount.ActUsername + " deleted"
+			}
+		}
+	case "changeuserpassword":
+		for _, info := range targets {
+			deviceAccount := new(manager.DeviceAccount)
+			deviceAccount.IpAddress = info[0] + ":" + info[1]
+			deviceAccount.UserOrToken = info[2]
+			deviceAccount.ActUsername = info[3]
+			deviceAccount.ActPassword = info[4]
+			_, err := cc.ChangeDeviceUserPassword(ctx, deviceAccount)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("change user password error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + deviceAccount.IpAddress + " changed"
+			}
+		}
+	case "storecreds":
+		for _, info := range targets {
+			ipAddress := info[0] + ":" + info[1]
+			if err := storeCredentials(ipAddress, info[2], info[3]); err != nil {
+				newmessage = newmessage + ipAddress + " store credentials error: " + err.Error() + "\n"
+				logrus.Errorf("store credentials error: %v", err)
+				continue
+			}
+			newmessage = newmessage + ipAddress + " credentials of " + info[2] + " stored\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "logindevice":
+		for _, info := range targets {
+			deviceAccount := new(manager.DeviceAccount)
+			deviceAccount.IpAddress = info[0] + ":" + info[1]
+			if len(info) == 3 {
+				credentials, err := lookupCredentials(deviceAccount.IpAddress)
 				if err != nil {
-					newmessage = newmessage + "invalid command " + devinfo
+					newmessage = newmessage + err.Error()
+					logrus.Errorf("login device error: %v", err)
 					continue
 				}
-				deviceinfo.Frequency = uint32(freq)
-				devicelist.Device = append(devicelist.Device, deviceinfo)
-				ipattached = append(ipattached, deviceinfo.IpAddress)
+				info = []string{info[0], info[1], credentials.Username, credentials.Password, info[2]}
+			}
+			deviceAccount.ActUsername = info[2]
+			deviceAccount.ActPassword = info[3]
+			basicAuth := new(manager.BasicAuth)
+			basicAuth.Enabled, _ = strconv.ParseBool(info[4])
+			if basicAuth.Enabled {
+				basicAuth.UserName = info[2]
+				basicAuth.Password = info[3]
+			}
+			deviceAccount.BasicAuth = basicAuth
+			retMsg, err := cc.LoginDevice(ctx, deviceAccount)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("login device error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				logrus.Info("logindevice user-data ", retMsg.Httptoken)
+				newmessage = newmessage + deviceAccount.IpAddress + " user-data : " + retMsg.Httptoken + " logined"
+			}
+		}
+	case "logoutdevice":
+		for _, info := range targets {
+			deviceAccount := new(manager.DeviceAccount)
+			deviceAccount.IpAddress = info[0] + ":" + info[1]
+			deviceAccount.UserOrToken = info[2]
+			deviceAccount.ActUsername = info[3]
+			_, err := cc.LogoutDevice(ctx, deviceAccount)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("logout device error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + deviceAccount.ActUsername + " logouted"
+			}
+		}
+	case "startquerydevice":
+		for _, info := range targets {
+			device := new(manager.Device)
+			device.IpAddress = info[0] + ":" + info[1]
+			device.UserOrToken = info[2]
+			_, err := cc.StartQueryDeviceData(ctx, device)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("logout device error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + device.IpAddress + " started"
 			}
-			if len(devicelist.Device) == 0 {
-				break
+		}
+	case "stopquerydevice":
+		for _, info := range targets {
+			device := new(manager.Device)
+			device.IpAddress = info[0] + ":" + info[1]
+			device.UserOrToken = info[2]
+			_, err := cc.StopQueryDeviceData(ctx, device)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("logout device error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + device.IpAddress + " stopped"
 			}
-			_, err := cc.SendDeviceList(ctx, &devicelist)
+		}
+	case "addpollingrfapi":
+		for _, info := range targets {
+			rfList := new(manager.Device)
+			rfList.IpAddress = info[0] + ":" + info[1]
+			rfList.UserOrToken = info[2]
+			rfList.PollingDataRfAPI = info[3]
+			_, err := cc.AddPollingRfAPI(ctx, rfList)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
 				newmessage = newmessage + errStatus.Message()
-				logrus.Errorf("attach error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				logrus.Errorf("adding polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
 			} else {
-				ips := strings.Join(ipattached, " ")
-				newmessage = newmessage + ips + " attached"
+				newmessage = newmessage + " added"
 			}
-		case "detach":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
+		}
+	case "removepollingrfapi":
+		for _, info := range targets {
+			rfList := new(manager.Device)
+			rfList.IpAddress = info[0] + ":" + info[1]
+			rfList.UserOrToken = info[2]
+			rfList.PollingDataRfAPI = info[3]
+			_, err := cc.RemovePollingRfAPI(ctx, rfList)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("removing polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + " removed"
 			}
-			device := new(manager.Device)
-			args := strings.Split(s[1], ":")
-			if len(args) != 3 {
-				newmessage = newmessage + "invalid command " + s[1]
-				break
+		}
+	case "clearpollingrfapi":
+		for _, info := range targets {
+			rfList := new(manager.Device)
+			rfList.IpAddress = info[0] + ":" + info[1]
+			rfList.UserOrToken = info[2]
+			_, err := cc.ClearPollingRfAPI(ctx, rfList)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("clearing polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + " cleared"
 			}
-			device.IpAddress = args[0] + ":" + args[1]
-			device.UserOrToken = args[2]
-			_, err := cc.DeleteDeviceList(ctx, device)
+		}
+	case "getpollingrflist":
+		for _, info := range targets {
+			rfList := new(manager.Device)
+			rfList.IpAddress = info[0] + ":" + info[1]
+			rfList.UserOrToken = info[2]
+			retMsg, err := cc.GetRfAPIList(ctx, rfList)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
 				newmessage = newmessage + errStatus.Message()
-				logrus.Errorf("detach error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				logrus.Errorf("list polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
 			} else {
-				newmessage = newmessage + device.IpAddress + " detached"
+				logrus.Info(retMsg.RfAPIList[:])
+				sort.Strings(retMsg.RfAPIList[:])
+				s := fmt.Sprint(retMsg.RfAPIList[:])
+				newmessage = newmessage + "Polling Redfish API list : " + s
 			}
-		case "period":
-			if len(s) != 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
+		}
+	case "setpollinglist":
+		for _, info := range targets {
+			pollingList := new(manager.PollingRfAPIList)
+			pollingList.IpAddress = info[0] + ":" + info[1]
+			pollingList.UserOrToken = info[2]
+			pollingList.RfAPIList = strings.Split(info[3], ",")
+			retMsg, err := cc.SetPollingRfAPIList(ctx, pollingList)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set polling Redfish API list error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			args := strings.Split(s[1], ":")
-			if len(args) != 4 {
-				newmessage = newmessage + "invalid command " + s[1]
-				break
+			newmessage = newmessage + pollingList.IpAddress + " Polling Redfish API list : " + fmt.Sprint(retMsg.RfAPIList) + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "pollnow":
+		for _, info := range targets {
+			pollNow := new(manager.PollNow)
+			pollNow.IpAddress = info[0] + ":" + info[1]
+			pollNow.UserOrToken = info[2]
+			retMsg, err := cc.PollDeviceNow(ctx, pollNow)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("poll device now error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			ip := args[0] + ":" + args[1]
-			token := args[2]
-			pv := args[3]
-			u, err := strconv.ParseUint(pv, 10, 64)
+			newmessage = newmessage + fmt.Sprintf("%s polled %d of %d Redfish APIs", pollNow.IpAddress, retMsg.Polled, retMsg.Total)
+			if retMsg.LastError != "" {
+				newmessage = newmessage + ", last error: " + retMsg.LastError
+			}
+			newmessage = newmessage + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "deviceaccountslist":
+		for _, info := range targets {
+			deviceAccount := new(manager.DeviceAccount)
+			deviceAccount.IpAddress = info[0] + ":" + info[1]
+			deviceAccount.UserOrToken = info[2]
+			deviceAccountList, err := cc.ListDeviceAccounts(ctx, deviceAccount)
 			if err != nil {
-				logrus.Error("ParseUint error!!")
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("list device accounts error - status code %v message %v", errStatus.Code(), errStatus.Message())
 			} else {
-				freqinfo := new(manager.Device)
-				freqinfo.Frequency = uint32(u)
-				freqinfo.IpAddress = ip
-				freqinfo.UserOrToken = token
-				_, err := cc.SetFrequency(ctx, freqinfo)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("period error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage
-				}
+				logrus.Info(deviceAccountList)
+				s := fmt.Sprint(deviceAccountList)
+				newmessage = newmessage + "accounts list : " + s
 			}
-		case "QUIT":
-			loop = false
-			newmessage = "QUIT"
-		case "showdevices":
-			cmdSize := len(s)
-			logrus.Infof("cmd is : %s cmdSize: %d", cmd, cmdSize)
-			if cmdSize > 2 || cmdSize < 0 {
-				logrus.Error("error showdevices !!")
-				newmessage = "error showdevices !!"
+		}
+	case "setsessionservice":
+		for _, info := range targets {
+			deviceAccount := new(manager.DeviceAccount)
+			deviceAccount.IpAddress = info[0] + ":" + info[1]
+			deviceAccount.UserOrToken = info[2]
+			deviceAccount.SessionEnabled, _ = strconv.ParseBool(info[3])
+			deviceAccount.SessionTimeout, _ = strconv.ParseUint(info[4], 10, 64)
+			_, err := cc.SetSessionService(ctx, deviceAccount)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("set seesion service error - status code %v. %v", errStatus.Code(), errStatus.Message())
 			} else {
-				currentlist, err := GetCurrentDevices()
-
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					logrus.Errorf("GetCurrentDevice error: %s Status code: %d", errStatus.Message(), errStatus.Code())
-					newmessage = errStatus.Message()
-					logrus.Info("showdevices error!!")
-				} else {
-					logrus.Info("showdevices ", currentlist)
-					newmessage = strings.Join(currentlist[:], " ")
-				}
+				newmessage = newmessage + deviceAccount.IpAddress + " set ok!"
 			}
-		case "createaccount":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+		}
+	case "getsessionservice":
+		info := targets[0]
+		deviceAccount := new(manager.DeviceAccount)
+		deviceAccount.IpAddress = info[0] + ":" + info[1]
+		deviceAccount.UserOrToken = info[2]
+		retMsg, err := cc.GetSessionService(ctx, deviceAccount)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = newmessage + errStatus.Message()
+			logrus.Errorf("get session service error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = newmessage + deviceAccount.IpAddress + " session enabled: " + strconv.FormatBool(retMsg.SessionEnabled) +
+				" session timeout: " + strconv.FormatUint(retMsg.SessionTimeout, 10)
+		}
+	case "getaccountpolicy":
+		for _, info := range targets {
+			policy := new(manager.AccountServicePolicy)
+			policy.IpAddress = info[0] + ":" + info[1]
+			policy.UserOrToken = info[2]
+			retMsg, err := cc.GetAccountServicePolicy(ctx, policy)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get account policy error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 6 {
-					newmessage = newmessage + "invalid command " + devinfo
+			newmessage = newmessage + formatAccountPolicy(policy.IpAddress, retMsg) + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setaccountpolicy":
+		for _, info := range targets {
+			policy := new(manager.AccountServicePolicy)
+			policy.IpAddress = info[0] + ":" + info[1]
+			policy.UserOrToken = info[2]
+			values := []*uint32{&policy.AccountLockoutThreshold, &policy.AccountLockoutDuration, &policy.MinPasswordLength}
+			invalid := ""
+			for i, property := range []string{"AccountLockoutThreshold", "AccountLockoutDuration", "MinPasswordLength"} {
+				if info[3+i] == "" {
 					continue
 				}
-				deviceAccount := new(manager.DeviceAccount)
-				deviceAccount.IpAddress = info[0] + ":" + info[1]
-				deviceAccount.UserOrToken = info[2]
-				deviceAccount.ActUsername = info[3]
-				deviceAccount.ActPassword = info[4]
-				deviceAccount.Privilege = info[5]
-				_, err := cc.CreateDeviceAccount(ctx, deviceAccount)
+				value, err := strconv.ParseUint(info[3+i], 10, 32)
 				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("create user account error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + deviceAccount.ActUsername + " created"
+					invalid = "invalid " + property + " " + info[3+i] + ", expected a non-negative integer"
+					break
 				}
+				*values[i] = uint32(value)
+				policy.SetProperties = append(policy.SetProperties, property)
 			}
-		case "deleteaccount":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			if invalid != "" {
+				newmessage = newmessage + invalid + "\n"
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 4 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				deviceAccount := new(manager.DeviceAccount)
-				deviceAccount.IpAddress = info[0] + ":" + info[1]
-				deviceAccount.UserOrToken = info[2]
-				deviceAccount.ActUsername = info[3]
-				_, err := cc.RemoveDeviceAccount(ctx, deviceAccount)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("delete user account error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + deviceAccount.ActUsername + " deleted"
-				}
+			retMsg, err := cc.SetAccountServicePolicy(ctx, policy)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set account policy error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-		case "changeuserpassword":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			newmessage = newmessage + formatAccountPolicy(policy.IpAddress, retMsg) + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getdeviceresettype":
+		info := targets[0]
+		resetTypeData := new(manager.SystemBoot)
+		resetTypeData.IpAddress = info[0] + ":" + info[1]
+		resetTypeData.UserOrToken = info[2]
+		retMsg, err := cc.GetDeviceSupportedResetType(ctx, resetTypeData)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = newmessage + errStatus.Message()
+			logrus.Errorf("getting device reset type error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			s := fmt.Sprint(retMsg.SupportedResetType)
+			newmessage = newmessage + s
+		}
+	case "resetdevicesystem":
+		info := targets[0]
+		bootData := new(manager.SystemBoot)
+		bootData.IpAddress = info[0] + ":" + info[1]
+		bootData.UserOrToken = info[2]
+		bootData.ResetType = info[3]
+		_, err := cc.ResetDeviceSystem(ctx, bootData)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = newmessage + errStatus.Message()
+			logrus.Errorf("resetting device system error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = newmessage + bootData.IpAddress + " reset device system ok!"
+		}
+	case "resetbmc":
+		info := targets[0]
+		bootData := new(manager.SystemBoot)
+		bootData.IpAddress = info[0] + ":" + info[1]
+		bootData.UserOrToken = info[2]
+		bootData.ResetType = info[3]
+		bootData.Confirmed = info[4] == "yes"
+		if !bootData.Confirmed {
+			newmessage = newmessage + "resetbmc wipes the BMC configuration, confirm with 'yes' as the last argument"
+			break
+		}
+		_, err := cc.ResetBmcToDefaults(ctx, bootData)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = newmessage + errStatus.Message()
+			logrus.Errorf("resetting BMC to defaults error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = newmessage + bootData.IpAddress + " reset BMC to defaults ok!"
+		}
+	case "setlogservice":
+		for _, info := range targets {
+			deviceLogService := new(manager.LogService)
+			deviceLogService.IpAddress = info[0] + ":" + info[1]
+			deviceLogService.UserOrToken = info[2]
+			deviceLogService.Id = info[3]
+			deviceLogService.LogServiceEnabled, _ = strconv.ParseBool(info[4])
+			_, err := cc.EnableLogServiceState(ctx, deviceLogService)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("set log service state error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + deviceLogService.IpAddress + " set ok!"
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 5 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				deviceAccount := new(manager.DeviceAccount)
-				deviceAccount.IpAddress = info[0] + ":" + info[1]
-				deviceAccount.UserOrToken = info[2]
-				deviceAccount.ActUsername = info[3]
-				deviceAccount.ActPassword = info[4]
-				_, err := cc.ChangeDeviceUserPassword(ctx, deviceAccount)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("change user password error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + deviceAccount.IpAddress + " changed"
-				}
+		}
+	case "resetlogdata":
+		for _, info := range targets {
+			deviceLogService := new(manager.LogService)
+			deviceLogService.IpAddress = info[0] + ":" + info[1]
+			deviceLogService.UserOrToken = info[2]
+			deviceLogService.Id = info[3]
+			_, err := cc.ResetDeviceLogData(ctx, deviceLogService)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("reset log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + deviceLogService.IpAddress + " set ok!"
 			}
-		case "logindevice":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+		}
+	case "getdevicelogdata":
+		args := targets[0]
+		deviceLogService := new(manager.LogService)
+		deviceLogService.IpAddress = args[0] + ":" + args[1]
+		deviceLogService.UserOrToken = args[2]
+		if len(args) == 4 {
+			deviceLogService.Id = args[3]
+		}
+		retMsg, err := cc.GetDeviceLogData(ctx, deviceLogService)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get device log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			logrus.Info("getdevicelogdata ", retMsg.LogData)
+			sort.Strings(retMsg.LogData[:])
+			newmessage = strings.Join(retMsg.LogData[:], " ")
+		}
+	case "listlogservices":
+		args := targets[0]
+		deviceLogService := new(manager.LogService)
+		deviceLogService.IpAddress = args[0] + ":" + args[1]
+		deviceLogService.UserOrToken = args[2]
+		retMsg, err := cc.ListLogServices(ctx, deviceLogService)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("list log services error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			services := []string{}
+			for _, service := range retMsg.LogServices {
+				services = append(services, service.Id+" "+service.OdataId+" enabled="+strconv.FormatBool(service.ServiceEnabled))
+			}
+			newmessage = strings.Join(services, "\n")
+		}
+	case "geteventservice":
+		for _, info := range targets {
+			eventServiceStatus := new(manager.EventServiceStatus)
+			eventServiceStatus.IpAddress = info[0] + ":" + info[1]
+			eventServiceStatus.UserOrToken = info[2]
+			retMsg, err := cc.GetEventServiceStatus(ctx, eventServiceStatus)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get event service error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 5 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				deviceAccount := new(manager.DeviceAccount)
-				deviceAccount.IpAddress = info[0] + ":" + info[1]
-				deviceAccount.ActUsername = info[2]
-				deviceAccount.ActPassword = info[3]
-				basicAuth := new(manager.BasicAuth)
-				basicAuth.Enabled, _ = strconv.ParseBool(info[4])
-				if basicAuth.Enabled {
-					basicAuth.UserName = info[2]
-					basicAuth.Password = info[3]
-				}
-				deviceAccount.BasicAuth = basicAuth
-				retMsg, err := cc.LoginDevice(ctx, deviceAccount)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("login device error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					logrus.Info("logindevice user-data ", retMsg.Httptoken)
-					newmessage = newmessage + deviceAccount.IpAddress + " user-data : " + retMsg.Httptoken + " logined"
+			newmessage = newmessage + eventServiceStatus.IpAddress + " enabled=" + strconv.FormatBool(retMsg.ServiceEnabled) + " health=" + retMsg.Health + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "seteventservice":
+		for _, info := range targets {
+			enabled, err := strconv.ParseBool(info[3])
+			if err != nil {
+				newmessage = newmessage + "invalid value " + info[3] + ", expected true or false\n"
+				continue
+			}
+			eventServiceStatus := new(manager.EventServiceStatus)
+			eventServiceStatus.IpAddress = info[0] + ":" + info[1]
+			eventServiceStatus.UserOrToken = info[2]
+			eventServiceStatus.ServiceEnabled = enabled
+			_, err = cc.SetEventServiceEnabled(ctx, eventServiceStatus)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set event service error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + eventServiceStatus.IpAddress + " set ok!\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getserialconsole":
+		for _, info := range targets {
+			consoleStatus := new(manager.SerialConsoleStatus)
+			consoleStatus.IpAddress = info[0] + ":" + info[1]
+			consoleStatus.UserOrToken = info[2]
+			retMsg, err := cc.GetSerialConsoleStatus(ctx, consoleStatus)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get serial console error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			for _, console := range retMsg.Consoles {
+				connections := []string{}
+				for _, connection := range console.Connections {
+					connections = append(connections, connection.ConnectType+":"+strconv.FormatUint(uint64(connection.Port), 10))
 				}
+				newmessage = newmessage + fmt.Sprintf("%s %s enabled=%t max sessions: %d connections: %s\n", consoleStatus.IpAddress, console.Name,
+					console.ServiceEnabled, console.MaxConcurrentSessions, strings.Join(connections, " "))
 			}
-		case "logoutdevice":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setserialconsole":
+		for _, info := range targets {
+			enabled, err := strconv.ParseBool(info[4])
+			if err != nil {
+				newmessage = newmessage + "invalid value " + info[4] + ", expected true or false\n"
+				continue
+			}
+			consoleStatus := new(manager.SerialConsoleStatus)
+			consoleStatus.IpAddress = info[0] + ":" + info[1]
+			consoleStatus.UserOrToken = info[2]
+			consoleStatus.Console = info[3]
+			consoleStatus.ServiceEnabled = enabled
+			_, err = cc.SetSerialConsoleEnabled(ctx, consoleStatus)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set serial console error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + consoleStatus.IpAddress + " set ok!\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setipmilan":
+		for _, info := range targets {
+			enabled, err := strconv.ParseBool(info[3])
+			if err != nil {
+				newmessage = newmessage + "invalid value " + info[3] + ", expected true or false\n"
+				continue
+			}
+			ipmiOverLan := new(manager.IpmiOverLan)
+			ipmiOverLan.IpAddress = info[0] + ":" + info[1]
+			ipmiOverLan.UserOrToken = info[2]
+			ipmiOverLan.Enabled = enabled
+			_, err = cc.SetIpmiOverLan(ctx, ipmiOverLan)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set IPMI-over-LAN error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 4 {
-					newmessage = newmessage + "invalid command " + devinfo
+			newmessage = newmessage + ipmiOverLan.IpAddress + " set ok!\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "maintenance":
+		for _, info := range targets {
+			maintenanceWindow := new(manager.MaintenanceWindow)
+			maintenanceWindow.IpAddress = info[0] + ":" + info[1]
+			maintenanceWindow.UserOrToken = info[2]
+			if info[3] != "0" || info[4] != "0" {
+				start, err := parseWindowTime(info[3], time.Now())
+				if err != nil {
+					newmessage = newmessage + err.Error() + "\n"
 					continue
 				}
-				deviceAccount := new(manager.DeviceAccount)
-				deviceAccount.IpAddress = info[0] + ":" + info[1]
-				deviceAccount.UserOrToken = info[2]
-				deviceAccount.ActUsername = info[3]
-				_, err := cc.LogoutDevice(ctx, deviceAccount)
+				end, err := parseWindowTime(info[4], time.Unix(start, 0))
 				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("logout device error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + deviceAccount.ActUsername + " logouted"
+					newmessage = newmessage + err.Error() + "\n"
+					continue
 				}
+				maintenanceWindow.Start, maintenanceWindow.End = start, end
 			}
-		case "startquerydevice":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			_, err := cc.SetMaintenanceWindow(ctx, maintenanceWindow)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set maintenance window error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 3 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				device := new(manager.Device)
-				device.IpAddress = info[0] + ":" + info[1]
-				device.UserOrToken = info[2]
-				_, err := cc.StartQueryDeviceData(ctx, device)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("logout device error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + device.IpAddress + " started"
-				}
+			if maintenanceWindow.End == 0 {
+				newmessage = newmessage + maintenanceWindow.IpAddress + " maintenance window cleared\n"
+				continue
 			}
-		case "stopquerydevice":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			newmessage = newmessage + fmt.Sprintf("%s maintenance from %s to %s\n", maintenanceWindow.IpAddress,
+				time.Unix(maintenanceWindow.Start, 0).Format(time.RFC3339), time.Unix(maintenanceWindow.End, 0).Format(time.RFC3339))
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setsyslog":
+		args := targets[0]
+		port, err := strconv.ParseUint(args[4], 10, 32)
+		if err != nil {
+			newmessage = newmessage + "invalid syslog port " + args[4]
+			break
+		}
+		syslogTarget := new(manager.SyslogTarget)
+		syslogTarget.IpAddress = args[0] + ":" + args[1]
+		syslogTarget.UserOrToken = args[2]
+		syslogTarget.Host = args[3]
+		syslogTarget.Port = uint32(port)
+		_, err = cc.SetSyslogTarget(ctx, syslogTarget)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("set syslog target error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = syslogTarget.IpAddress + " set ok!"
+		}
+	case "getdatetime":
+		for _, info := range targets {
+			managerDateTime := new(manager.ManagerDateTime)
+			managerDateTime.IpAddress = info[0] + ":" + info[1]
+			managerDateTime.UserOrToken = info[2]
+			retMsg, err := cc.GetManagerDateTime(ctx, managerDateTime)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get date time error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 3 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				device := new(manager.Device)
-				device.IpAddress = info[0] + ":" + info[1]
-				device.UserOrToken = info[2]
-				_, err := cc.StopQueryDeviceData(ctx, device)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("logout device error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + device.IpAddress + " stopped"
-				}
+			newmessage = newmessage + managerDateTime.IpAddress + " " + retMsg.DateTime + " " + retMsg.DateTimeLocalOffset
+			if deviceTime, err := time.Parse(time.RFC3339, retMsg.DateTime); err == nil {
+				newmessage = newmessage + " skew " + deviceTime.Sub(time.Now()).Round(time.Second).String()
 			}
-		case "addpollingrfapi":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			newmessage = newmessage + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setdatetime":
+		for _, info := range targets {
+			managerDateTime := new(manager.ManagerDateTime)
+			managerDateTime.IpAddress = info[0] + ":" + info[1]
+			managerDateTime.UserOrToken = info[2]
+			if info[3] == "now" {
+				managerDateTime.DateTime = time.Now().UTC().Format(time.RFC3339)
+			} else if seconds, err := strconv.ParseInt(info[3], 10, 64); err == nil {
+				managerDateTime.DateTime = time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+			} else {
+				newmessage = newmessage + "invalid time " + info[3]
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 4 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
+			if len(info) == 5 {
+				offset := info[4]
+				if len(offset) == 5 {
+					offset = offset[:3] + ":" + offset[3:]
 				}
-				rfList := new(manager.Device)
-				rfList.IpAddress = info[0] + ":" + info[1]
-				rfList.UserOrToken = info[2]
-				rfList.PollingDataRfAPI = info[3]
-				_, err := cc.AddPollingRfAPI(ctx, rfList)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("adding polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				managerDateTime.DateTimeLocalOffset = offset
+			}
+			_, err := cc.SetManagerDateTime(ctx, managerDateTime)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("set date time error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + managerDateTime.IpAddress + " set to " + managerDateTime.DateTime
+			}
+		}
+	case "getbmccert":
+		for _, info := range targets {
+			managerCertificate := new(manager.ManagerCertificate)
+			managerCertificate.IpAddress = info[0] + ":" + info[1]
+			managerCertificate.UserOrToken = info[2]
+			retMsg, err := cc.GetManagerCertificate(ctx, managerCertificate)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get BMC certificate error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + managerCertificate.IpAddress + " subject: " + retMsg.Subject + " issuer: " + retMsg.Issuer + " valid not after: " + retMsg.ValidNotAfter
+			if notAfter, err := time.Parse(time.RFC3339, retMsg.ValidNotAfter); err == nil {
+				days := int(time.Until(notAfter).Hours() / 24)
+				if days < 0 {
+					newmessage = newmessage + " (expired " + strconv.Itoa(-days) + " days ago)"
 				} else {
-					newmessage = newmessage + " added"
+					newmessage = newmessage + " (expires in " + strconv.Itoa(days) + " days)"
 				}
 			}
-		case "removepollingrfapi":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			newmessage = newmessage + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setbmccert":
+		for _, info := range targets {
+			certificate, err := ioutil.ReadFile(info[3])
+			if err != nil {
+				newmessage = newmessage + "read certificate error: " + err.Error()
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 4 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
+			privateKey, err := ioutil.ReadFile(info[4])
+			if err != nil {
+				newmessage = newmessage + "read private key error: " + err.Error()
+				continue
+			}
+			managerCertificate := new(manager.ManagerCertificate)
+			managerCertificate.IpAddress = info[0] + ":" + info[1]
+			managerCertificate.UserOrToken = info[2]
+			managerCertificate.CertificateString = joinCertificateKey(certificate, privateKey)
+			_, err = cc.ReplaceManagerCertificate(ctx, managerCertificate)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("replace BMC certificate error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + managerCertificate.IpAddress + " certificate replaced"
+			}
+		}
+	case "gethealth":
+		for _, info := range targets {
+			healthRollup := new(manager.HealthRollup)
+			healthRollup.IpAddress = info[0] + ":" + info[1]
+			healthRollup.UserOrToken = info[2]
+			retMsg, err := cc.GetHealthRollup(ctx, healthRollup)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get health rollup error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + healthRollup.IpAddress + " " + worstHealth(retMsg.Resources) + "\n"
+			for _, resource := range retMsg.Resources {
+				newmessage = newmessage + "\t" + resource.Kind + " " + resource.OdataId + ": " + resource.HealthRollup + "\n"
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "healthall":
+		newmessage = healthAll(targets[0][0])
+	case "pollcheck":
+		maxAge := ""
+		if len(targets[0]) > 1 {
+			maxAge = targets[0][1]
+		}
+		newmessage = pollCheck(targets[0][0], maxAge)
+	case "fleetget":
+		newmessage = fleetGet(targets[0][0], targets[0][1])
+	case "periodall":
+		newmessage = periodAll(targets[0][0], targets[0][1])
+	case "findpollingrfapi":
+		newmessage = findPollingRfAPI(targets[0][0], targets[0][1])
+	case "finddupes":
+		newmessage = findDuplicateDevices()
+	case "unschedule":
+		for _, info := range targets {
+			newmessage = newmessage + removeSchedule(info[0]) + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "listschedules":
+		newmessage = listSchedules()
+	case "getportstats":
+		args := targets[0]
+		networkPortStats := new(manager.NetworkPortStats)
+		networkPortStats.IpAddress = args[0] + ":" + args[1]
+		networkPortStats.UserOrToken = args[2]
+		retMsg, err := cc.GetNetworkPortStats(ctx, networkPortStats)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get network port stats error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			ports := []string{}
+			for _, port := range retMsg.Ports {
+				ports = append(ports, fmt.Sprintf("%s rx bytes: %d tx bytes: %d rx errors: %d tx errors: %d", port.OdataId, port.RxBytes, port.TxBytes, port.RxErrors, port.TxErrors))
+			}
+			newmessage = strings.Join(ports, "\n")
+		}
+	case "getfanredundancy":
+		for _, info := range targets {
+			thermalRedundancy := new(manager.ThermalRedundancy)
+			thermalRedundancy.IpAddress = info[0] + ":" + info[1]
+			thermalRedundancy.UserOrToken = info[2]
+			retMsg, err := cc.GetThermalRedundancy(ctx, thermalRedundancy)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get fan redundancy error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			for _, redundancy := range retMsg.Redundancies {
+				newmessage = newmessage + fmt.Sprintf("%s %s mode: %s min needed: %d members: %d state: %s health: %s %s\n", thermalRedundancy.IpAddress, redundancy.Name,
+					redundancy.Mode, redundancy.MinNumNeeded, redundancy.MemberCount, redundancy.State, redundancy.Health, redundancy.Status)
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getpowerredundancy":
+		for _, info := range targets {
+			powerRedundancy := new(manager.PowerRedundancy)
+			powerRedundancy.IpAddress = info[0] + ":" + info[1]
+			powerRedundancy.UserOrToken = info[2]
+			retMsg, err := cc.GetPowerRedundancy(ctx, powerRedundancy)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get power redundancy error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			for _, redundancy := range retMsg.Redundancies {
+				newmessage = newmessage + fmt.Sprintf("%s %s mode: %s min needed: %d members: %d state: %s health: %s %s\n", powerRedundancy.IpAddress, redundancy.Name,
+					redundancy.Mode, redundancy.MinNumNeeded, redundancy.MemberCount, redundancy.State, redundancy.Health, redundancy.Status)
+			}
+			for _, supply := range retMsg.PowerSupplies {
+				newmessage = newmessage + fmt.Sprintf("%s %s input: %s %gV %gW state: %s health: %s\n", powerRedundancy.IpAddress, supply.Name,
+					supply.LineInputVoltageType, supply.LineInputVoltage, supply.PowerInputWatts, supply.State, supply.Health)
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getbootprogress":
+		for _, info := range targets {
+			bootProgress := new(manager.BootProgress)
+			bootProgress.IpAddress = info[0] + ":" + info[1]
+			bootProgress.UserOrToken = info[2]
+			retMsg, err := cc.GetBootProgress(ctx, bootProgress)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get boot progress error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			lastState := retMsg.LastState
+			if lastState == "OEM" && retMsg.OemLastState != "" {
+				lastState = lastState + " (" + retMsg.OemLastState + ")"
+			}
+			newmessage = newmessage + bootProgress.IpAddress + " PowerState: " + retMsg.PowerState + " LastState: " + lastState + " at " + retMsg.LastStateTime + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getpciedevices":
+		for _, info := range targets {
+			pcieDevices := new(manager.PCIeDevices)
+			pcieDevices.IpAddress = info[0] + ":" + info[1]
+			pcieDevices.UserOrToken = info[2]
+			retMsg, err := cc.GetPCIeDevices(ctx, pcieDevices)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get PCIe devices error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			for _, device := range retMsg.Devices {
+				newmessage = newmessage + fmt.Sprintf("%s %s type: %s class: %s manufacturer: %s model: %s firmware: %s state: %s health: %s\n", pcieDevices.IpAddress,
+					device.Name, device.DeviceType, device.DeviceClass, device.Manufacturer, device.Model, device.FirmwareVersion, device.State, device.Health)
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getgputelemetry":
+		for _, info := range targets {
+			telemetry := new(manager.AcceleratorTelemetry)
+			telemetry.IpAddress = info[0] + ":" + info[1]
+			telemetry.UserOrToken = info[2]
+			retMsg, err := cc.GetAcceleratorTelemetry(ctx, telemetry)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get accelerator telemetry error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			if len(retMsg.Accelerators) == 0 {
+				newmessage = newmessage + telemetry.IpAddress + " no accelerators\n"
+				continue
+			}
+			for _, gpu := range retMsg.Accelerators {
+				newmessage = newmessage + fmt.Sprintf("%s %s type: %s model: %s temperature: %vC power: %vW utilization: %v%% state: %s health: %s", telemetry.IpAddress,
+					gpu.Name, gpu.ProcessorType, gpu.Model, gpu.TemperatureCelsius, gpu.PowerWatts, gpu.UtilizationPercent, gpu.State, gpu.Health)
+				var names []string
+				for name := range gpu.OemMetrics {
+					names = append(names, name)
 				}
-				rfList := new(manager.Device)
-				rfList.IpAddress = info[0] + ":" + info[1]
-				rfList.UserOrToken = info[2]
-				rfList.PollingDataRfAPI = info[3]
-				_, err := cc.RemovePollingRfAPI(ctx, rfList)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("removing polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + " removed"
+				sort.Strings(names)
+				for _, name := range names {
+					newmessage = newmessage + " " + name + ": " + gpu.OemMetrics[name]
 				}
+				newmessage = newmessage + "\n"
 			}
-		case "clearpollingrfapi":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
-			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 3 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "checkconformance":
+		for _, info := range targets {
+			conformance := new(manager.RedfishConformance)
+			conformance.IpAddress = info[0] + ":" + info[1]
+			conformance.UserOrToken = info[2]
+			retMsg, err := cc.CheckRedfishConformance(ctx, conformance)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("check conformance error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			for _, result := range retMsg.Results {
+				newmessage = newmessage + fmt.Sprintf("%s %s %s %s", conformance.IpAddress, result.Name, result.Uri, result.Status)
+				if result.Detail != "" {
+					newmessage = newmessage + " (" + result.Detail + ")"
 				}
-				rfList := new(manager.Device)
-				rfList.IpAddress = info[0] + ":" + info[1]
-				rfList.UserOrToken = info[2]
-				_, err := cc.ClearPollingRfAPI(ctx, rfList)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("clearing polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + " cleared"
+				newmessage = newmessage + "\n"
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getmfginfo":
+		for _, info := range targets {
+			manufacturingInfo := new(manager.ManufacturingInfo)
+			manufacturingInfo.IpAddress = info[0] + ":" + info[1]
+			manufacturingInfo.UserOrToken = info[2]
+			retMsg, err := cc.GetManufacturingInfo(ctx, manufacturingInfo)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get manufacturing info error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			fields := []string{}
+			for _, field := range [][]string{{"manufacturer", retMsg.Manufacturer}, {"model", retMsg.Model}, {"serial number", retMsg.SerialNumber},
+				{"part number", retMsg.PartNumber}, {"SKU", retMsg.Sku}, {"manufacture date", retMsg.ManufactureDate}} {
+				if field[1] != "" {
+					fields = append(fields, field[0]+": "+field[1])
 				}
 			}
-		case "getpollingrflist":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			warranty := []string{}
+			for name, value := range retMsg.Warranty {
+				warranty = append(warranty, name+": "+value)
+			}
+			sort.Strings(warranty)
+			newmessage = newmessage + manufacturingInfo.IpAddress + " " + strings.Join(append(fields, warranty...), " ") + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getlocation":
+		for _, info := range targets {
+			physicalLocation := new(manager.PhysicalLocation)
+			physicalLocation.IpAddress = info[0] + ":" + info[1]
+			physicalLocation.UserOrToken = info[2]
+			retMsg, err := cc.GetPhysicalLocation(ctx, physicalLocation)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get physical location error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			for _, location := range retMsg.Locations {
+				newmessage = newmessage + fmt.Sprintf("%s %s slot: %s %s %d row: %s rack: %s position: %s %d contained by: %s\n", physicalLocation.IpAddress,
+					location.Name, location.ServiceLabel, location.LocationType, location.LocationOrdinalValue, location.Row, location.Rack,
+					location.RackOffsetUnits, location.RackOffset, location.ContainedBy)
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getwatchdog":
+		for _, info := range targets {
+			watchdog := new(manager.Watchdog)
+			watchdog.IpAddress = info[0] + ":" + info[1]
+			watchdog.UserOrToken = info[2]
+			retMsg, err := cc.GetWatchdog(ctx, watchdog)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get watchdog error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + fmt.Sprintf("%s enabled=%t timeout action: %s (%s) warning action: %s", watchdog.IpAddress, retMsg.FunctionEnabled,
+				retMsg.TimeoutAction, strings.Join(retMsg.TimeoutActionAllowed, " "), retMsg.WarningAction)
+			if retMsg.TimeoutSeconds != 0 {
+				newmessage = newmessage + fmt.Sprintf(" timeout: %ds", retMsg.TimeoutSeconds)
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 3 {
-					newmessage = newmessage + "invalid command " + devinfo
+			newmessage = newmessage + " state: " + retMsg.State + " health: " + retMsg.Health + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setwatchdog":
+		for _, info := range targets {
+			enabled, err := strconv.ParseBool(info[3])
+			if err != nil {
+				newmessage = newmessage + "invalid value " + info[3] + ", expected true or false\n"
+				continue
+			}
+			watchdog := new(manager.Watchdog)
+			watchdog.IpAddress = info[0] + ":" + info[1]
+			watchdog.UserOrToken = info[2]
+			watchdog.FunctionEnabled = enabled
+			if len(info) > 4 {
+				watchdog.TimeoutAction = info[4]
+			}
+			if len(info) > 5 && info[5] != "" {
+				seconds, err := strconv.ParseUint(info[5], 10, 32)
+				if err != nil || seconds == 0 {
+					newmessage = newmessage + "invalid timeout " + info[5] + "\n"
 					continue
 				}
-				rfList := new(manager.Device)
-				rfList.IpAddress = info[0] + ":" + info[1]
-				rfList.UserOrToken = info[2]
-				retMsg, err := cc.GetRfAPIList(ctx, rfList)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("list polling Redfish API error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					logrus.Info(retMsg.RfAPIList[:])
-					sort.Strings(retMsg.RfAPIList[:])
-					s := fmt.Sprint(retMsg.RfAPIList[:])
-					newmessage = newmessage + "Polling Redfish API list : " + s
-				}
+				watchdog.TimeoutSeconds = uint32(seconds)
 			}
-		case "deviceaccountslist":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			_, err = cc.SetWatchdog(ctx, watchdog)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set watchdog error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 3 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				deviceAccount := new(manager.DeviceAccount)
-				deviceAccount.IpAddress = info[0] + ":" + info[1]
-				deviceAccount.UserOrToken = info[2]
-				deviceAccountList, err := cc.ListDeviceAccounts(ctx, deviceAccount)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("list device accounts error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					logrus.Info(deviceAccountList)
-					s := fmt.Sprint(deviceAccountList)
-					newmessage = newmessage + "accounts list : " + s
-				}
+			newmessage = newmessage + watchdog.IpAddress + " set ok!\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getfancontrol":
+		for _, info := range targets {
+			fanControl := new(manager.FanControl)
+			fanControl.IpAddress = info[0] + ":" + info[1]
+			fanControl.UserOrToken = info[2]
+			retMsg, err := cc.GetFanControl(ctx, fanControl)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get fan control error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-		case "setsessionservice":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			newmessage = newmessage + fmt.Sprintf("%s manual control supported=%t mode: %s (%s)", fanControl.IpAddress, retMsg.ManualSupported,
+				retMsg.Mode, strings.Join(retMsg.ModeAllowed, " "))
+			if retMsg.PwmSupported {
+				newmessage = newmessage + fmt.Sprintf(" PWM: %d%%", retMsg.PwmPercent)
+			}
+			for _, fan := range retMsg.Fans {
+				newmessage = newmessage + fmt.Sprintf("\n%s %s: %v %s", fanControl.IpAddress, fan.Name, fan.Reading, fan.Units)
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 5 {
-					newmessage = newmessage + "invalid command " + devinfo
+			newmessage = newmessage + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setfancontrol":
+		for _, info := range targets {
+			fanControl := new(manager.FanControl)
+			fanControl.IpAddress = info[0] + ":" + info[1]
+			fanControl.UserOrToken = info[2]
+			fanControl.Mode = info[3]
+			if len(info) > 4 && info[4] != "" {
+				percent, err := strconv.ParseUint(info[4], 10, 32)
+				if err != nil || percent == 0 || percent > 100 {
+					newmessage = newmessage + "invalid PWM " + info[4] + ", expected 1 to 100\n"
 					continue
 				}
-				deviceAccount := new(manager.DeviceAccount)
-				deviceAccount.IpAddress = info[0] + ":" + info[1]
-				deviceAccount.UserOrToken = info[2]
-				deviceAccount.SessionEnabled, _ = strconv.ParseBool(info[3])
-				deviceAccount.SessionTimeout, _ = strconv.ParseUint(info[4], 10, 64)
-				_, err := cc.SetSessionService(ctx, deviceAccount)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("set seesion service error - status code %v. %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + deviceAccount.IpAddress + " set ok!"
-				}
+				fanControl.PwmPercent = uint32(percent)
 			}
-		case "getdeviceresettype":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			_, err := cc.SetFanControl(ctx, fanControl)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set fan control error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			info := strings.Split(s[1], ":")
-			if len(info) != 3 {
-				newmessage = newmessage + "invalid command " + s[1]
-				break
+			newmessage = newmessage + fanControl.IpAddress + " set ok!\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getbootpolicy":
+		for _, info := range targets {
+			bootPolicy := new(manager.BootPolicy)
+			bootPolicy.IpAddress = info[0] + ":" + info[1]
+			bootPolicy.UserOrToken = info[2]
+			retMsg, err := cc.GetBootPolicy(ctx, bootPolicy)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get boot policy error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			resetTypeData := new(manager.SystemBoot)
-			resetTypeData.IpAddress = info[0] + ":" + info[1]
-			resetTypeData.UserOrToken = info[2]
-			retMsg, err := cc.GetDeviceSupportedResetType(ctx, resetTypeData)
+			newmessage = newmessage + bootPolicy.IpAddress + " AutomaticRetryConfig: " + retMsg.AutomaticRetryConfig + " (" + strings.Join(retMsg.AutomaticRetryConfigAllowed, " ") + ")" +
+				" PowerRestorePolicy: " + retMsg.PowerRestorePolicy + " (" + strings.Join(retMsg.PowerRestorePolicyAllowed, " ") + ")\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setbootpolicy":
+		for _, info := range targets {
+			bootPolicy := new(manager.BootPolicy)
+			bootPolicy.IpAddress = info[0] + ":" + info[1]
+			bootPolicy.UserOrToken = info[2]
+			bootPolicy.AutomaticRetryConfig = info[3]
+			bootPolicy.PowerRestorePolicy = info[4]
+			_, err := cc.SetBootPolicy(ctx, bootPolicy)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
 				newmessage = newmessage + errStatus.Message()
-				logrus.Errorf("getting device reset type error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				logrus.Errorf("set boot policy error - status code %v message %v", errStatus.Code(), errStatus.Message())
 			} else {
-				s := fmt.Sprint(retMsg.SupportedResetType)
-				newmessage = newmessage + s
-			}
-		case "resetdevicesystem":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+				newmessage = newmessage + bootPolicy.IpAddress + " boot policy set"
 			}
-			info := strings.Split(s[1], ":")
-			if len(info) != 4 {
-				newmessage = newmessage + "invalid command " + s[1]
-				break
+		}
+	case "getbootorder":
+		for _, info := range targets {
+			bootOrder := new(manager.BootOrder)
+			bootOrder.IpAddress = info[0] + ":" + info[1]
+			bootOrder.UserOrToken = info[2]
+			retMsg, err := cc.GetBootOrder(ctx, bootOrder)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get boot order error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + bootOrder.IpAddress + "\n"
+			for i, option := range retMsg.Options {
+				newmessage = newmessage + fmt.Sprintf("\t%d %s", i+1, option.Reference)
+				if option.DisplayName != "" && option.DisplayName != option.Reference {
+					newmessage = newmessage + " " + option.DisplayName
+				}
+				newmessage = newmessage + "\n"
 			}
-			bootData := new(manager.SystemBoot)
-			bootData.IpAddress = info[0] + ":" + info[1]
-			bootData.UserOrToken = info[2]
-			bootData.ResetType = info[3]
-			_, err := cc.ResetDeviceSystem(ctx, bootData)
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "clearbootoverride":
+		for _, info := range targets {
+			bootOverride := new(manager.BootOverride)
+			bootOverride.IpAddress = info[0] + ":" + info[1]
+			bootOverride.UserOrToken = info[2]
+			retMsg, err := cc.ClearBootOverride(ctx, bootOverride)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
-				newmessage = newmessage + errStatus.Message()
-				logrus.Errorf("resetting device system error - status code %v message %v", errStatus.Code(), errStatus.Message())
-			} else {
-				newmessage = newmessage + bootData.IpAddress + " reset device system ok!"
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("clear boot override error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-		case "setlogservice":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			newmessage = newmessage + fmt.Sprintf("%s boot override was %s (target %s, mode %s), now Disabled\n", bootOverride.IpAddress, retMsg.Enabled, retMsg.Target, retMsg.Mode)
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getcapacity":
+		for _, info := range targets {
+			capacity := new(manager.SystemCapacity)
+			capacity.IpAddress = info[0] + ":" + info[1]
+			capacity.UserOrToken = info[2]
+			retMsg, err := cc.GetSystemCapacity(ctx, capacity)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get capacity error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 5 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
-				}
-				deviceLogService := new(manager.LogService)
-				deviceLogService.IpAddress = info[0] + ":" + info[1]
-				deviceLogService.UserOrToken = info[2]
-				deviceLogService.Id = info[3]
-				deviceLogService.LogServiceEnabled, _ = strconv.ParseBool(info[4])
-				_, err := cc.EnableLogServiceState(ctx, deviceLogService)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("set log service state error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + deviceLogService.IpAddress + " set ok!"
+			newmessage = newmessage + fmt.Sprintf("%s systems: %d memory: %g GiB logical processors: %d\n", capacity.IpAddress, retMsg.SystemCount, retMsg.TotalMemoryGiB, retMsg.LogicalProcessorCount)
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getallowablevalues":
+		for _, info := range targets {
+			actionData := new(manager.ActionAllowableValues)
+			actionData.IpAddress = info[0] + ":" + info[1]
+			actionData.UserOrToken = info[2]
+			actionData.Action = info[3]
+			retMsg, err := cc.GetActionAllowableValues(ctx, actionData)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get allowable values error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			newmessage = newmessage + actionData.IpAddress + "\n"
+			for _, action := range retMsg.Actions {
+				newmessage = newmessage + "\t" + action.Name + " " + action.Target + "\n"
+				for _, parameter := range action.Parameters {
+					newmessage = newmessage + "\t\t" + parameter.Name + ": " + strings.Join(parameter.AllowableValues, " ") + "\n"
 				}
 			}
-		case "resetlogdata":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "exportbios":
+		info := targets[0]
+		newmessage = exportBios(info[0]+":"+info[1], info[2], info[3])
+	case "importbios":
+		for _, info := range targets {
+			newmessage = newmessage + importBios(info[0]+":"+info[1], info[2], info[3]) + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getdevicetemperaturedata":
+		args := targets[0]
+		deviceTemperature := new(manager.DeviceTemperature)
+		deviceTemperature.IpAddress = args[0] + ":" + args[1]
+		deviceTemperature.UserOrToken = args[2]
+		retMsg, err := cc.GetDeviceTemperatures(ctx, deviceTemperature)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get device temperature data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			logrus.Info("getdevicetemeraturedata ", retMsg.TempData)
+			sort.Strings(retMsg.TempData[:])
+			newmessage = strings.Join(retMsg.TempData[:], " ")
+		}
+	case "gettempthresholds":
+		for _, info := range targets {
+			thresholds := new(manager.TemperatureThresholds)
+			thresholds.IpAddress = info[0] + ":" + info[1]
+			thresholds.UserOrToken = info[2]
+			retMsg, err := cc.GetTemperatureThresholds(ctx, thresholds)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get temperature thresholds error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 4 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
+			celsius := func(value float64) string {
+				if value == 0 {
+					return "-"
 				}
-				deviceLogService := new(manager.LogService)
-				deviceLogService.IpAddress = info[0] + ":" + info[1]
-				deviceLogService.UserOrToken = info[2]
-				deviceLogService.Id = info[3]
-				_, err := cc.ResetDeviceLogData(ctx, deviceLogService)
+				return strconv.FormatFloat(value, 'g', -1, 64) + "C"
+			}
+			newmessage = newmessage + thresholds.IpAddress + "\n"
+			for _, threshold := range retMsg.Thresholds {
+				newmessage = newmessage + fmt.Sprintf("\t%s %s (%s): reading %s critical %s fatal %s headroom %s\n", threshold.Chassis, threshold.MemberID, threshold.Name,
+					celsius(threshold.ReadingCelsius), celsius(threshold.UpperThresholdCritical), celsius(threshold.UpperThresholdFatal), celsius(threshold.HeadroomCelsius))
+			}
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setdevicetemperaturedata":
+		args := targets[0]
+		ip := args[0] + ":" + args[1]
+		token := args[2]
+		memberID := args[3]
+		upperThresholdNonCritical := args[4]
+		upper, err1 := strconv.ParseUint(upperThresholdNonCritical, 10, 64)
+		lowerThresholdNonCritical := args[5]
+		lower, err2 := strconv.ParseUint(lowerThresholdNonCritical, 10, 64)
+		if err1 != nil || err2 != nil {
+			logrus.Error("ParseUint error!!")
+		} else {
+			deviceTempinfo := new(manager.DeviceTemperature)
+			deviceTempinfo.IpAddress = ip
+			deviceTempinfo.UserOrToken = token
+			deviceTempinfo.MemberID = memberID
+			deviceTempinfo.UpperThresholdNonCritical = uint32(upper)
+			deviceTempinfo.LowerThresholdNonCritical = uint32(lower)
+			_, err := cc.SetDeviceTemperatureForEvent(ctx, deviceTempinfo)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("period error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			} else {
+				newmessage = newmessage + cmd + " configured"
+			}
+		}
+	case "devicesoftwareupdate":
+		for _, info := range targets {
+			applyTime := ""
+			if len(info) == 10 {
+				applyTime = info[9]
+			}
+			if len(info) >= 9 && info[8] == "push" {
+				taskURI, err := pushSoftwareImage(info[0]+":"+info[1], info[2], info[7], applyTime)
 				if err != nil {
 					errStatus, _ := status.FromError(err)
 					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("reset log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+					logrus.Errorf("push software image error - status code %v message %v", errStatus.Code(), errStatus.Message())
 				} else {
-					newmessage = newmessage + deviceLogService.IpAddress + " set ok!"
+					newmessage = newmessage + info[0] + ":" + info[1] + " pushed, task " + taskURI
 				}
+				continue
+			}
+			if len(info) >= 9 && info[8] != "pull" && info[8] != "" {
+				newmessage = newmessage + "invalid transfer method " + info[8]
+				continue
+			}
+			deviceSoftware := new(manager.SoftwareUpdate)
+			deviceSoftware.IpAddress = info[0] + ":" + info[1]
+			deviceSoftware.UserOrToken = info[2]
+			deviceSoftware.SoftwareDownloadType = info[3]
+			deviceSoftware.ApplyTime = applyTime
+			if info[6] == "" {
+				deviceSoftware.SoftwareDownloadURI = info[4] + "://" + info[5] + "/" + info[7]
+			} else {
+				deviceSoftware.SoftwareDownloadURI = info[4] + "://" + info[5] + ":" + info[6] + "/" + info[7]
 			}
-		case "getdevicelogdata":
-			if len(s) != 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
-			}
-			args := strings.Split(s[1], ":")
-			if len(args) < 4 {
-				newmessage = newmessage + "invalid command " + args[0]
-				break
-			}
-			deviceLogService := new(manager.LogService)
-			deviceLogService.IpAddress = args[0] + ":" + args[1]
-			deviceLogService.UserOrToken = args[2]
-			deviceLogService.Id = args[3]
-			retMsg, err := cc.GetDeviceLogData(ctx, deviceLogService)
+			_, err := cc.SendDeviceSoftwareDownloadURI(ctx, deviceSoftware)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
-				newmessage = errStatus.Message()
-				logrus.Errorf("get device log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				newmessage = newmessage + errStatus.Message()
+				logrus.Errorf("reset log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
 			} else {
-				logrus.Info("getdevicelogdata ", retMsg.LogData)
-				sort.Strings(retMsg.LogData[:])
-				newmessage = strings.Join(retMsg.LogData[:], " ")
-			}
-		case "getdevicetemperaturedata":
-			if len(s) != 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
+				newmessage = newmessage + deviceSoftware.IpAddress + " set ok!"
 			}
-			args := strings.Split(s[1], ":")
-			if len(args) < 3 {
-				newmessage = newmessage + "invalid command " + args[0]
-				break
+		}
+	case "getupdatestatus":
+		for _, info := range targets {
+			updateStatus := new(manager.UpdateServiceStatus)
+			updateStatus.IpAddress = info[0] + ":" + info[1]
+			updateStatus.UserOrToken = info[2]
+			retMsg, err := cc.GetUpdateServiceStatus(ctx, updateStatus)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get update service status error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			ready := retMsg.ServiceEnabled && retMsg.ActiveTask == ""
+			newmessage = newmessage + updateStatus.IpAddress + " enabled: " + strconv.FormatBool(retMsg.ServiceEnabled) + " health: " + retMsg.Health +
+				" protocols: " + strings.Join(retMsg.TransferProtocols, " ") + " multipart push: " + strconv.FormatBool(retMsg.MultipartPush) +
+				" apply times: " + strings.Join(retMsg.ApplyTimes, " ")
+			if retMsg.ActiveTask != "" {
+				newmessage = newmessage + fmt.Sprintf(" busy with %s (%s %d%%)", retMsg.ActiveTask, retMsg.ActiveTaskState, retMsg.ActiveTaskPercent)
+			}
+			if len(info) == 4 {
+				supported := false
+				for _, protocol := range retMsg.TransferProtocols {
+					supported = supported || strings.EqualFold(protocol, info[3])
+				}
+				ready = ready && supported
+				newmessage = newmessage + " " + strings.ToUpper(info[3]) + " supported: " + strconv.FormatBool(supported)
 			}
-			deviceTemperature := new(manager.DeviceTemperature)
-			deviceTemperature.IpAddress = args[0] + ":" + args[1]
-			deviceTemperature.UserOrToken = args[2]
-			retMsg, err := cc.GetDeviceTemperatures(ctx, deviceTemperature)
+			newmessage = newmessage + " ready: " + strconv.FormatBool(ready) + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "cancelupdate":
+		info := targets[0]
+		deviceSoftware := new(manager.SoftwareUpdate)
+		deviceSoftware.IpAddress = info[0] + ":" + info[1]
+		deviceSoftware.UserOrToken = info[2]
+		task, err := cc.CancelSoftwareUpdate(ctx, deviceSoftware)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = newmessage + errStatus.Message()
+			logrus.Errorf("cancel software update error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else if task.TaskURI == "" {
+			newmessage = newmessage + deviceSoftware.IpAddress + " no active update task found"
+		} else {
+			newmessage = newmessage + deviceSoftware.IpAddress + " cancelled task " + task.TaskURI
+		}
+	case "getfirmwareinventory":
+		info := targets[0]
+		inventory := new(manager.FirmwareInventory)
+		inventory.IpAddress = info[0] + ":" + info[1]
+		inventory.UserOrToken = info[2]
+		retMsg, err := cc.GetFirmwareInventory(ctx, inventory)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get firmware inventory error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			components := []string{}
+			for _, component := range retMsg.Components {
+				components = append(components, component.Id+" "+component.Name+" "+component.Version)
+			}
+			sort.Strings(components)
+			newmessage = strings.Join(components, "\n")
+		}
+	case "getfwbanks":
+		for _, info := range targets {
+			firmwareBanks := new(manager.ManagerFirmwareBanks)
+			firmwareBanks.IpAddress = info[0] + ":" + info[1]
+			firmwareBanks.UserOrToken = info[2]
+			retMsg, err := cc.GetManagerFirmwareBanks(ctx, firmwareBanks)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
-				newmessage = errStatus.Message()
-				logrus.Errorf("get device temperature data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get manager firmware banks error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
+			}
+			activeBank := retMsg.ActiveBank
+			if activeBank == "" {
+				activeBank = "not reported"
+			}
+			newmessage = newmessage + firmwareBanks.IpAddress + " " + retMsg.Manager + "\n\tactive: " + retMsg.ActiveVersion + " (bank " + activeBank + ")\n"
+			if retMsg.BackupBank == "" {
+				newmessage = newmessage + "\tbackup: none (single firmware bank)\n"
 			} else {
-				logrus.Info("getdevicetemeraturedata ", retMsg.TempData)
-				sort.Strings(retMsg.TempData[:])
-				newmessage = strings.Join(retMsg.TempData[:], " ")
+				newmessage = newmessage + "\tbackup: " + retMsg.BackupVersion + " (bank " + retMsg.BackupBank + ")\n"
 			}
-		case "setdevicetemperaturedata":
-			if len(s) != 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "switchfwbank":
+		for _, info := range targets {
+			bankSwitch := new(manager.FirmwareBankSwitch)
+			bankSwitch.IpAddress = info[0] + ":" + info[1]
+			bankSwitch.UserOrToken = info[2]
+			retMsg, err := cc.SwitchFirmwareBank(ctx, bankSwitch)
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("switch firmware bank error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			args := strings.Split(s[1], ":")
-			if len(args) != 6 {
-				newmessage = newmessage + "invalid command " + s[1]
-				break
+			newmessage = newmessage + bankSwitch.IpAddress + " " + retMsg.Manager + " switched from " + retMsg.PreviousVersion + " to " + retMsg.TargetVersion + " (bank " + retMsg.TargetBank + ")"
+			if retMsg.ResetRequired {
+				newmessage = newmessage + ", reset the manager to boot from it"
 			}
-			ip := args[0] + ":" + args[1]
-			token := args[2]
-			memberID := args[3]
-			upperThresholdNonCritical := args[4]
-			upper, err1 := strconv.ParseUint(upperThresholdNonCritical, 10, 64)
-			lowerThresholdNonCritical := args[5]
-			lower, err2 := strconv.ParseUint(lowerThresholdNonCritical, 10, 64)
-			if err1 != nil || err2 != nil {
-				logrus.Error("ParseUint error!!")
+			newmessage = newmessage + "\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "gethostname", "sethostname":
+		for _, info := range targets {
+			hostName := new(manager.HostName)
+			hostName.IpAddress = info[0] + ":" + info[1]
+			hostName.UserOrToken = info[2]
+			var retMsg *manager.HostName
+			var err error
+			if cmd == "sethostname" {
+				hostName.HostName = info[3]
+				retMsg, err = cc.SetHostName(ctx, hostName)
 			} else {
-				deviceTempinfo := new(manager.DeviceTemperature)
-				deviceTempinfo.IpAddress = ip
-				deviceTempinfo.UserOrToken = token
-				deviceTempinfo.MemberID = memberID
-				deviceTempinfo.UpperThresholdNonCritical = uint32(upper)
-				deviceTempinfo.LowerThresholdNonCritical = uint32(lower)
-				_, err := cc.SetDeviceTemperatureForEvent(ctx, deviceTempinfo)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("period error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + cmd + " configured"
-				}
+				retMsg, err = cc.GetHostName(ctx, hostName)
 			}
-		case "devicesoftwareupdate":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("%s error - status code %v message %v", cmd, errStatus.Code(), errStatus.Message())
+				continue
 			}
-			for _, devinfo := range s[1:] {
-				info := strings.Split(devinfo, ":")
-				if len(info) != 8 {
-					newmessage = newmessage + "invalid command " + devinfo
-					continue
+			newmessage = newmessage + hostName.IpAddress + " host name: " + retMsg.HostName
+			if retMsg.Fqdn != "" {
+				newmessage = newmessage + " FQDN: " + retMsg.Fqdn
+			}
+			newmessage = newmessage + " (" + retMsg.Interface + ")\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getdevicedata":
+		args := targets[0]
+		currentdeviceinfo := new(manager.Device)
+		currentdeviceinfo.IpAddress = args[0] + ":" + args[1]
+		currentdeviceinfo.UserOrToken = args[2]
+		currentdeviceinfo.RedfishAPI = args[3]
+		if len(args) == 5 {
+			currentdeviceinfo.JsonPath = args[4]
+		}
+		retMsg, err := cc.GetDeviceData(ctx, currentdeviceinfo)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get device data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			logrus.Info("getdevicedata ", retMsg.DeviceData)
+			sort.Strings(retMsg.DeviceData[:])
+			newmessage = strings.Join(retMsg.DeviceData[:], " ")
+		}
+	case "diffdevices":
+		args := targets[0]
+		deviceDiff := new(manager.DeviceDiff)
+		deviceDiff.IpAddress1 = args[0] + ":" + args[1]
+		deviceDiff.UserOrToken1 = args[2]
+		deviceDiff.IpAddress2 = args[3] + ":" + args[4]
+		deviceDiff.UserOrToken2 = args[5]
+		deviceDiff.RedfishAPI = args[6]
+		retMsg, err := cc.DiffDevices(ctx, deviceDiff)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("diff devices error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else if len(retMsg.Difference) == 0 {
+			newmessage = "no difference"
+		} else {
+			diffs := []string{}
+			for _, diff := range retMsg.Difference {
+				switch diff.Kind {
+				case "changed":
+					diffs = append(diffs, "~ "+diff.Path+": "+diff.Value1+" -> "+diff.Value2)
+				case "only in first":
+					diffs = append(diffs, "- "+diff.Path+": "+diff.Value1)
+				default:
+					diffs = append(diffs, "+ "+diff.Path+": "+diff.Value2)
 				}
-				deviceSoftware := new(manager.SoftwareUpdate)
-				deviceSoftware.IpAddress = info[0] + ":" + info[1]
-				deviceSoftware.UserOrToken = info[2]
-				deviceSoftware.SoftwareDownloadType = info[3]
-				if info[6] == "" {
-					deviceSoftware.SoftwareDownloadURI = info[4] + "://" + info[5] + "/" + info[7]
-				} else {
-					deviceSoftware.SoftwareDownloadURI = info[4] + "://" + info[5] + ":" + info[6] + "/" + info[7]
+			}
+			newmessage = "--- " + deviceDiff.IpAddress1 + "\n+++ " + deviceDiff.IpAddress2 + "\n" + strings.Join(diffs, "\n")
+		}
+	case "deviceaccess":
+		args := targets[0]
+		currentdeviceinfo := new(manager.Device)
+		devicehttpinfo := new(manager.HttpInfo)
+		httppostdata := new(manager.HttpPostData)
+		httppatchdata := new(manager.HttpPatchData)
+		currentdeviceinfo.IpAddress = args[0] + ":" + args[1]
+		currentdeviceinfo.UserOrToken = args[2]
+		devicehttpinfo.HttpMethod = args[3]
+		currentdeviceinfo.RedfishAPI = args[4]
+		currentdeviceinfo.HttpInfo = devicehttpinfo
+		if len(devicehttpinfo.HttpMethod) != 0 {
+			switch devicehttpinfo.HttpMethod {
+			case "POST":
+				postData := map[string]string{}
+				postData["UserName"] = strings.Split(args[5], "/")[0]
+				postData["Password"] = strings.Split(args[5], "/")[1]
+				pdata := manager.HttpPostData{PostData: postData}
+				httppostdata.PostData = pdata.PostData
+				devicehttpinfo.HttpPostData = httppostdata
+				currentdeviceinfo.HttpInfo = devicehttpinfo
+			case "DELETE":
+				if args[5] == "" {
+					newmessage = newmessage + "It needs 6 arguments separating by ':'" + args[0]
+					break
 				}
-				_, err := cc.SendDeviceSoftwareDownloadURI(ctx, deviceSoftware)
-				if err != nil {
-					errStatus, _ := status.FromError(err)
-					newmessage = newmessage + errStatus.Message()
-					logrus.Errorf("reset log data error - status code %v message %v", errStatus.Code(), errStatus.Message())
-				} else {
-					newmessage = newmessage + deviceSoftware.IpAddress + " set ok!"
+				devicehttpinfo.HttpDeleteData = args[5]
+				currentdeviceinfo.HttpInfo = devicehttpinfo
+			case "PATCH":
+				if args[5] == "" {
+					newmessage = newmessage + "It needs 6 arguments separating by ':'" + args[0]
+					break
 				}
+				patchData := map[string]string{}
+				patchData["Password"] = args[5]
+				pdata := manager.HttpPatchData{PatchData: patchData}
+				httppatchdata.PatchData = pdata.PatchData
+				devicehttpinfo.HttpPatchData = httppatchdata
+				currentdeviceinfo.HttpInfo = devicehttpinfo
 			}
-		case "getdevicedata":
-			if len(s) != 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
-			}
-			args := strings.Split(s[1], ":")
-			if len(args) < 3 {
-				newmessage = newmessage + "invalid command " + args[0]
-				break
+		}
+		retMsg, err := cc.GenericDeviceAccess(ctx, currentdeviceinfo)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get device data error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = retMsg.ResultData
+		}
+	case "sethttpcontenttype":
+		args := targets[0]
+		device := new(manager.Device)
+		device.IpAddress = args[0] + ":" + args[1]
+		device.ContentType = args[2]
+		_, err := cc.SetHTTPApplication(ctx, device)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("Failed to set HTTP Content Type error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = newmessage + cmd + " configured"
+		}
+	case "setpolltimeout":
+		for _, info := range targets {
+			seconds, err := strconv.ParseUint(info[3], 10, 32)
+			if err != nil {
+				newmessage = newmessage + "invalid poll timeout " + info[3] + "\n"
+				continue
 			}
-			currentdeviceinfo := new(manager.Device)
-			currentdeviceinfo.IpAddress = args[0] + ":" + args[1]
-			currentdeviceinfo.UserOrToken = args[2]
-			currentdeviceinfo.RedfishAPI = args[3]
-			retMsg, err := cc.GetDeviceData(ctx, currentdeviceinfo)
+			device := new(manager.Device)
+			device.IpAddress = info[0] + ":" + info[1]
+			device.UserOrToken = info[2]
+			device.PollTimeout = uint32(seconds)
+			_, err = cc.SetPollTimeout(ctx, device)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
-				newmessage = errStatus.Message()
-				logrus.Errorf("get device data error - status code %v message %v", errStatus.Code(), errStatus.Message())
-			} else {
-				logrus.Info("getdevicedata ", retMsg.DeviceData)
-				sort.Strings(retMsg.DeviceData[:])
-				newmessage = strings.Join(retMsg.DeviceData[:], " ")
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set poll timeout error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-		case "deviceaccess":
-			if len(s) != 2 {
-				newmessage = newmessage + "1 invalid command " + cmdstr
-				break
+			newmessage = newmessage + device.IpAddress + " set ok!\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "sethttptype":
+		args := targets[0]
+		device := new(manager.Device)
+		device.IpAddress = args[0] + ":" + args[1]
+		device.HTTPType = args[2]
+		_, err := cc.SetHTTPType(ctx, device)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("Failed to set HTTP Type error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = newmessage + cmd + " configured"
+		}
+	case "simpleupdate":
+		info := targets[0]
+
+		simpleUpdate := new(manager.SimpleUpdateRequest)
+		simpleUpdate.IpAddress = info[0] + ":" + info[1]
+		simpleUpdate.UserOrToken = info[2]
+		simpleUpdate.ImageURI = info[3] + "://" + info[4]
+		if len(info) > 5 {
+			if info[5] != "" {
+				simpleUpdate.Targets = strings.Split(info[5], ",")
 			}
-			args := strings.Split(s[1], ":")
-			if len(args) != 5 && len(args) != 6 {
-				newmessage = newmessage + "2  invalid command " + args[0]
-				break
+			if info[6] != "" {
+				simpleUpdate.TransferProtocol = info[6]
 			}
-			currentdeviceinfo := new(manager.Device)
-			devicehttpinfo := new(manager.HttpInfo)
-			httppostdata := new(manager.HttpPostData)
-			httppatchdata := new(manager.HttpPatchData)
-			currentdeviceinfo.IpAddress = args[0] + ":" + args[1]
-			currentdeviceinfo.UserOrToken = args[2]
-			devicehttpinfo.HttpMethod = args[3]
-			currentdeviceinfo.RedfishAPI = args[4]
-			currentdeviceinfo.HttpInfo = devicehttpinfo
-			if len(devicehttpinfo.HttpMethod) != 0 {
-				switch devicehttpinfo.HttpMethod {
-				case "POST":
-					postData := map[string]string{}
-					postData["UserName"] = strings.Split(args[5], "/")[0]
-					postData["Password"] = strings.Split(args[5], "/")[1]
-					pdata := manager.HttpPostData{PostData: postData}
-					httppostdata.PostData = pdata.PostData
-					devicehttpinfo.HttpPostData = httppostdata
-					currentdeviceinfo.HttpInfo = devicehttpinfo
-				case "DELETE":
-					if args[5] == "" {
-						newmessage = newmessage + "It needs 6 arguments separating by ':'" + args[0]
-						break
-					}
-					devicehttpinfo.HttpDeleteData = args[5]
-					currentdeviceinfo.HttpInfo = devicehttpinfo
-				case "PATCH":
-					if args[5] == "" {
-						newmessage = newmessage + "It needs 6 arguments separating by ':'" + args[0]
-						break
-					}
-					patchData := map[string]string{}
-					patchData["Password"] = args[5]
-					pdata := manager.HttpPatchData{PatchData: patchData}
-					httppatchdata.PatchData = pdata.PatchData
-					devicehttpinfo.HttpPatchData = httppatchdata
-					currentdeviceinfo.HttpInfo = devicehttpinfo
+			if info[7] != "" {
+				simpleUpdate.Username = info[7]
+			}
+			if info[8] != "" {
+				simpleUpdate.Password = info[8]
+			}
+		}
+
+		task, err := cc.SimpleUpdate(ctx, simpleUpdate)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = newmessage + errStatus.Message()
+			logrus.Errorf("simple update error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			newmessage = newmessage + "Simple Update send " + task.TaskURI
+		}
+
+	case "getpowerhistory":
+		args := targets[0]
+		powerHistory := new(manager.PowerHistory)
+		powerHistory.IpAddress = args[0] + ":" + args[1]
+		powerHistory.UserOrToken = args[2]
+		retMsg, err := cc.GetPowerHistory(ctx, powerHistory)
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get power history error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			var samples []string
+			for _, sample := range retMsg.Samples {
+				timestamp := time.Unix(sample.Timestamp, 0).UTC().Format(time.RFC3339)
+				samples = append(samples, timestamp+" "+strconv.FormatFloat(sample.PowerConsumedWatts, 'f', -1, 64)+"W")
+			}
+			newmessage = strings.Join(samples, "\n")
+		}
+	case "fleettelemetry":
+		fleetTelemetry, err := cc.GetFleetTelemetry(ctx, new(manager.Empty))
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get fleet telemetry error - status code %v message %v", errStatus.Code(), errStatus.Message())
+		} else {
+			devices := []string{}
+			for _, device := range fleetTelemetry.Devices {
+				polledAt := "never"
+				if device.PolledAt != 0 {
+					polledAt = time.Unix(device.PolledAt, 0).UTC().Format(time.RFC3339)
 				}
+				devices = append(devices, fmt.Sprintf("%s %s polled: %s cpus: %d threads: %d cpu health: %s memory: %gGiB memory health: %s drives: %d storage: %d bytes",
+					device.IpAddress, device.State, polledAt, device.ProcessorCount, device.LogicalProcessorCount, device.ProcessorHealth,
+					device.MemoryGiB, device.MemoryHealth, device.DriveCount, device.StorageBytes))
 			}
-			retMsg, err := cc.GenericDeviceAccess(ctx, currentdeviceinfo)
+			newmessage = strings.Join(devices, "\n")
+		}
+	case "getpowercap":
+		for _, info := range targets {
+			powerCap := new(manager.PowerCap)
+			powerCap.IpAddress = info[0] + ":" + info[1]
+			powerCap.UserOrToken = info[2]
+			retMsg, err := cc.GetPowerCap(ctx, powerCap)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
-				newmessage = errStatus.Message()
-				logrus.Errorf("get device data error - status code %v message %v", errStatus.Code(), errStatus.Message())
-			} else {
-				newmessage = retMsg.ResultData
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get power cap error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-		case "sethttpcontenttype":
-			if len(s) != 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
-			}
-			args := strings.Split(s[1], ":")
-			if len(args) < 3 {
-				newmessage = newmessage + "invalid command " + args[0]
-				break
-			}
-			device := new(manager.Device)
-			device.IpAddress = args[0] + ":" + args[1]
-			device.ContentType = args[2]
-			_, err := cc.SetHTTPApplication(ctx, device)
+			newmessage = newmessage + fmt.Sprintf("%s limit: %dW active: %v range: %dW to %dW correction: %dms exception: %s\n", powerCap.IpAddress,
+				retMsg.LimitInWatts, retMsg.Active, retMsg.MinWatts, retMsg.MaxWatts, retMsg.CorrectionInMs, retMsg.LimitException)
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "setpowercap":
+		for _, info := range targets {
+			watts, err := strconv.ParseUint(info[3], 10, 32)
+			if err != nil {
+				newmessage = newmessage + "invalid power cap " + info[3] + "\n"
+				continue
+			}
+			powerCap := new(manager.PowerCap)
+			powerCap.IpAddress = info[0] + ":" + info[1]
+			powerCap.UserOrToken = info[2]
+			powerCap.LimitInWatts = uint32(watts)
+			_, err = cc.SetPowerCap(ctx, powerCap)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
-				newmessage = errStatus.Message()
-				logrus.Errorf("Failed to set HTTP Content Type error - status code %v message %v", errStatus.Code(), errStatus.Message())
-			} else {
-				newmessage = newmessage + cmd + " configured"
-			}
-		case "sethttptype":
-			if len(s) != 2 {
-				newmessage = newmessage + "invalid command " + cmdstr
-				break
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("set power cap error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-			args := strings.Split(s[1], ":")
-			if len(args) < 3 {
-				newmessage = newmessage + "invalid command " + args[0]
-				break
-			}
-			device := new(manager.Device)
-			device.IpAddress = args[0] + ":" + args[1]
-			device.HTTPType = args[2]
-			_, err := cc.SetHTTPType(ctx, device)
+			newmessage = newmessage + powerCap.IpAddress + " set ok!\n"
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "getpollstatus":
+		for _, info := range targets {
+			pollStatus := new(manager.PollStatus)
+			pollStatus.IpAddress = info[0] + ":" + info[1]
+			pollStatus.UserOrToken = info[2]
+			retMsg, err := cc.GetPollStatus(ctx, pollStatus)
 			if err != nil {
 				errStatus, _ := status.FromError(err)
-				newmessage = errStatus.Message()
-				logrus.Errorf("Failed to set HTTP Type error - status code %v message %v", errStatus.Code(), errStatus.Message())
-			} else {
-				newmessage = newmessage + cmd + " configured"
+				newmessage = newmessage + errStatus.Message() + "\n"
+				logrus.Errorf("get poll status error - status code %v message %v", errStatus.Code(), errStatus.Message())
+				continue
 			}
-		case "simpleupdate":
-			if len(s) < 2 {
-				newmessage = newmessage + "invalid command length" + cmdstr
-				break
+			lastAttempt, lastSuccess := "never", "never"
+			if retMsg.LastAttempt != 0 {
+				lastAttempt = time.Unix(retMsg.LastAttempt, 0).UTC().Format(time.RFC3339)
 			}
+			if retMsg.LastSuccess != 0 {
+				lastSuccess = time.Unix(retMsg.LastSuccess, 0).UTC().Format(time.RFC3339)
+			}
+			lastError := retMsg.LastError
+			if lastError == "" {
+				lastError = "none"
+			}
+			newmessage = newmessage + fmt.Sprintf("%s state: %s querying: %v last attempt: %s last success: %s consecutive failures: %d last error: %s\n", pollStatus.IpAddress,
+				retMsg.State, retMsg.QueryState, lastAttempt, lastSuccess, retMsg.ConsecutiveFailures, lastError)
+		}
+		newmessage = strings.TrimSuffix(newmessage, "\n")
+	case "help":
+		name, err := resolveCommand(targets[0][0])
+		if err != nil {
+			newmessage = newmessage + err.Error()
+			break
+		}
+		spec := findCommandSpec(name)
+		if spec == nil || spec.help == "" {
+			newmessage = newmessage + "unknown command '" + targets[0][0] + "'"
+			break
+		}
+		newmessage = newmessage + spec.help
+	case "version":
+		retMsg, err := cc.GetVersion(ctx, new(manager.Empty))
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			newmessage = errStatus.Message()
+			logrus.Errorf("get version error - status code %v message %v", errStatus.Code(), errStatus.Message())
+			break
+		}
+		newmessage = fmt.Sprintf("version: %s\ngit commit: %s\nbuild date: %s\ngo: %s\nredfish library: %s", retMsg.Version, retMsg.GitCommit,
+			retMsg.BuildDate, retMsg.GoVersion, retMsg.RedfishLibVersion)
+	case "showconfig":
+		newmessage = showConfig()
+	case "reloadconfig":
+		newmessage = reloadConfig(targets[0][0])
+	case "listcommands":
+		newmessage = newmessage + "The commands list :\n" + listCommandsUsage() + "\n"
+	default:
+		newmessage = newmessage + "3 invalid command " + cmdstr
+	}
+	return newmessage
+}
+
+func main() {
+	ParseCommandLine()
+	ProcessGlobalOptions()
+	ShowGlobalOptions()
+	if err := setupLogFile(GlobalConfig.LogFile, GlobalConfig.LogMaxSizeMB); err != nil {
+		logrus.Fatalf("could not open log file: %v", err)
+	}
 
-			info := strings.Split(s[1], ":")
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	logrus.Info("Launching server...")
+	if GlobalConfig.Consumer {
+		logrus.Info("kafkaInit starting")
+		kafkainit()
+	}
 
-			simpleUpdate := new(manager.SimpleUpdateRequest)
-			simpleUpdate.IpAddress = info[0] + ":" + info[1]
-			simpleUpdate.UserOrToken = info[2]
-			simpleUpdate.ImageURI = info[3] + "://" + info[4]
-			if len(info) > 5 {
-				if info[5] != "" {
-					simpleUpdate.Targets = strings.Split(info[5], ",")
-				}
-				if info[6] != "" {
-					simpleUpdate.TransferProtocol = info[6]
-				}
-				if info[7] != "" {
-					simpleUpdate.Username = info[7]
-				}
-				if info[8] != "" {
-					simpleUpdate.Password = info[8]
-				}
-			}
+	ln, err := net.Listen("tcp", GlobalConfig.Local)
+	if err != nil {
+		fmt.Println("could not listen")
+		logrus.Fatalf("did not listen: %v", err)
+	}
+	defer ln.Close()
 
-			task, err := cc.SimpleUpdate(ctx, simpleUpdate)
-			if err != nil {
-				errStatus, _ := status.FromError(err)
-				newmessage = newmessage + errStatus.Message()
-				logrus.Errorf("simple update error - status code %v message %v", errStatus.Code(), errStatus.Message())
-			} else {
-				newmessage = newmessage + "Simple Update send " + task.TaskURI
-			}
+	conn, err = dialManager(GlobalConfig.Manager, false)
+	if err != nil {
+		logrus.Fatalf("did not connect: %v", err)
+	}
+	//reloadconfig may replace conn
+	defer func() { conn.Close() }()
+
+	cc = manager.NewDeviceManagementClient(conn)
+	ctx = managerContext()
+
+	loop := true
+
+	for loop {
+		connS, err := ln.Accept()
+		if err != nil {
+			logrus.Fatalf("Accept error: %v", err)
+		}
+		cmdstr, _ := bufio.NewReader(connS).ReadString('\n')
+		cmdstr = strings.TrimSuffix(cmdstr, "\n")
+		cmdstr, compress := splitCompressSuffix(cmdstr)
+		cmdstr, outputFile := splitOutputRedirect(cmdstr)
+		s := strings.Split(cmdstr, " ")
+		newmessage := ""
 
-		case "listcommands":
-			newmessage = newmessage + `The commands list :
-attach - attach a device and detect Device
-	Usage: ./dm attach <ip address:port:period:detect Device:Do not authenticate>
-detach - detach a device
-	Usage: ./dm detach <ip address:port:token>
-period - a period of quering device data
-	Usage: ./dm period <ip address:port:token:period>
-showdevices - show registered device
-	Usage: ./dm showdevices <none>
-createaccount - create an account
-	Usage: ./dm createaccount <ip address:port:token:username:password:privilege>
-deleteaccount - delete an account
-	Usage: ./dm deleteaccount <ip address:port:token:username>
-changeuserpassword - change user password
-	Usage: ./dm changeuserpassword <ip address:port:token:username:new passowrd>
-logindevice - login to device
-	Usage: ./dm logindevice <ip address:port:username:password:<false:Token/true:Basic Authentication>>
-logoutdevice - logout the device
-	Usage: ./dm logoutdevice <ip address:port:token:username>
-startquerydevice - start to query device
-	Usage: ./dm startquerydevice <ip address:port:token>
-stopquerydevice - stop to query device
-	Usage: ./dm stopquerydevice <ip address:port:token>
-deviceaccountslist - show device accounts
-	Usage: ./dm deviceaccountslist <ip address:port:token>
-setsessionservice - configure device authoriation
-	Usage: ./dm setsessionservice <ip address:port:token:<true or false>:session timeout>
-addpollingrfapi - add Redfish API to poll device data periodically
-	Usage: ./dm addpollingrfapi <ip address:port:token:Redfish API>
-removepollingrfapi - remove Redfish API from polling device data periodically
-	Usage: ./dm removepollingrfapi <ip address:port:token:Redfish API>
-clearpollingrfapi - clear Redfish API from polling device data periodically
-	Usage: ./dm clearpollingrfapi <ip address:port:token>
-getpollingrflist - show added Redfish API to poll device data periodically
-	Usage: ./dm getpollingrflist <ip address:port:token>
-setlogservice - enable/disable log service to device
-	Usage: ./dm setlogservice <ip address:port:token:log_id:<true or false>>
-resetlogdata - reset all log data to device
-	Usage: ./dm resetlogdata <ip address:port:token:log_id>
-getdevicelogdata - get all log data to device (maximum data count: 1000)
-	Usage: ./dm getdevicelogdata <ip address:port:token:log_id>
-getdeviceresettype - get device reset system type
-	Usage: ./dm getdeviceresettype <ip address:port:token>
-resetdevicesystem - reset device system (supported reset type is "GracefulRestart". BMC supports "ForceOn", "ForceOff" and "ForceReset")
-	Usage: ./dm resetdevicesystem <ip address:port:token:Reset type>
-getdevicetemperaturedata - get device tempertures infomation
-	Usage: ./dm getdevicetemperaturedata <ip address:port:token>
-setdevicetemperaturedata - configure the device event temperature
-	Usage: ./dm setdevicetemperaturedata <ip address:port:token:member id:upperThresholdNonCritical:lowerThresholdNonCritical>
-devicesoftwareupdate - start to update device and send Multiple Updater (MU) download site
-	Usage: ./dm devicesoftwareupdate <ip address:port:token:MU:<http or https or tftp>:<server IP address:<port or "">:multiple updater download URI>
-devicesoftwareupdate - start to update device and send Network OS (NOS) download site
-	Usage: ./dm devicesoftwareupdate <ip address:port:token:NOS:<http or https or tftp>:<server IP address:<port or "">:Network OS file download URI>
-devicesoftwareupdate - start to update device and send system install package download site
-	Usage: ./dm devicesoftwareupdate <ip address:port:token:PACKAGE:<http or https or tftp>:<server IP address:<port or "">:system package file download URI>
-getdevicedata - get device data from cache
-	Usage: ./dm getdevicedata <ip address:port:token:Redfish API>
-deviceaccess - access device data by Redfish API
-	Usage: ./dm deviceaccess <ip address:port:token:HTTP method:Redfish API:HTTP DELETE/PATCH data>
-sethttpcontenttype - set device HTTP Content Type
-	Usage: ./dm sethttpcontenttype <ip address:port:http content type>
-sethttptype - set device HTTP Type (http or https)
-	Usage: ./dm sethttpcontenttype <ip address:port:http or https>
-simpleupdate - send Simple Update
-	Usage: ./dm simpleupdate <ip address:port:token:file transfer protocol:imageUri:targets:transferProtocol:username:password
+		cmd, targets, err := parseCommandLine(s)
+		if err != nil {
+			newmessage = err.Error()
+			cmd = ""
+		}
+		if cmd == "schedule" && outputFile != "" {
+			//the output file belongs to the scheduled command line
+			s = append(s, ">", outputFile)
+			outputFile = ""
+		}
 
-`
+		switch cmd {
+		case "":
+			// argument validation failed, newmessage already holds the error
+		case "QUIT":
+			loop = false
+			newmessage = "QUIT"
+		case "streamdevicelogdata":
+			args := targets[0]
+			interval, err := strconv.ParseUint(args[3], 10, 32)
+			if err != nil || interval == 0 {
+				newmessage = newmessage + "invalid interval " + args[3]
+				break
+			}
+			deviceLogService := new(manager.LogService)
+			deviceLogService.IpAddress = args[0] + ":" + args[1]
+			deviceLogService.UserOrToken = args[2]
+			if len(args) == 5 {
+				deviceLogService.Id = args[4]
+			}
+			if compress {
+				connS.Write([]byte{responsePlain})
+			}
+			go streamDeviceLogData(connS, deviceLogService, time.Duration(interval)*time.Second)
+			continue
+		case "pulldevicelogdata":
+			args := targets[0]
+			deviceLogService := new(manager.LogService)
+			deviceLogService.IpAddress = args[0] + ":" + args[1]
+			deviceLogService.UserOrToken = args[2]
+			if len(args) == 4 {
+				deviceLogService.Id = args[3]
+			}
+			if compress {
+				connS.Write([]byte{responsePlain})
+				compress = false
+			}
+			newmessage = pullDeviceLogData(connS, deviceLogService, outputFile)
+			outputFile = ""
 		default:
-			newmessage = newmessage + "3 invalid command " + cmdstr
+			dispatchLock.Lock()
+			newmessage = dispatchCommand(cmd, targets, s, cmdstr)
+			dispatchLock.Unlock()
+		}
+		if outputFile != "" && cmd != "" && cmd != "QUIT" {
+			newmessage = writeCommandOutput(outputFile, newmessage)
 		}
 		// send string back to client
-		n, err := connS.Write([]byte(newmessage + "\n" + ";"))
+		response := []byte(newmessage + "\n" + ";")
+		if compress {
+			response = encodeResponse(newmessage)
+		}
+		n, err := connS.Write(response)
 		if err != nil {
 			logrus.Errorf("err writing to client:%s, n:%d", err, n)
 			return
@@ -0,0 +1,138 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//acceleratorTypes are the ProcessorType values of the Processors reported by getAcceleratorTelemetry
+var acceleratorTypes = map[string]bool{"GPU": true, "Accelerator": true, "FPGA": true, "DSP": true}
+
+//acceleratorOemKeys match the OEM metrics of an accelerator, lowercase
+var acceleratorOemKeys = []string{"temp", "power", "util"}
+
+//accelerator holds the telemetry of one GPU or other accelerator Processor
+type accelerator struct {
+	OdataID            string
+	Name               string
+	ProcessorType      string
+	Model              string
+	State              string
+	Health             string
+	TemperatureCelsius float64
+	PowerWatts         float64
+	UtilizationPercent float64
+	OemMetrics         map[string]string
+}
+
+/* firstOemReading() returns the first numeric OEM metric whose property name contains key, in path order
+ */
+func firstOemReading(oemMetrics map[string]string, key string) (float64, bool) {
+	var paths []string
+	for path := range oemMetrics {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if name := path[strings.LastIndex(path, ".")+1:]; !strings.Contains(strings.ToLower(name), key) {
+			continue
+		}
+		if reading, err := strconv.ParseFloat(oemMetrics[path], 64); err == nil {
+			return reading, true
+		}
+	}
+	return 0, false
+}
+
+/* readAccelerator() reads the metrics of an accelerator Processor, the ProcessorMetrics and EnvironmentMetrics
+ * resources come first and the OEM metrics of the processor and of its ProcessorMetrics fill the values they lack
+ */
+func readAccelerator(deviceIPAddress, odataID string, processorData map[string]interface{}, userAuthData userAuth) accelerator {
+	gpu := accelerator{OdataID: odataID, OemMetrics: map[string]string{}}
+	gpu.Name, _ = processorData["Name"].(string)
+	gpu.ProcessorType, _ = processorData["ProcessorType"].(string)
+	gpu.Model, _ = processorData["Model"].(string)
+	processorStatus, _ := processorData["Status"].(map[string]interface{})
+	gpu.State, _ = processorStatus["State"].(string)
+	gpu.Health, _ = processorStatus["Health"].(string)
+	findOemFields(processorData["Oem"], "", acceleratorOemKeys, gpu.OemMetrics)
+	var hasTemperature, hasPower, hasUtilization bool
+	if metricsURI := getOdataID(processorData, "Metrics"); metricsURI != "" {
+		metricsData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, metricsURI, userAuthData)
+		gpu.TemperatureCelsius, hasTemperature = metricsData["TemperatureCelsius"].(float64)
+		gpu.PowerWatts, hasPower = metricsData["ConsumedPowerWatt"].(float64)
+		gpu.UtilizationPercent, hasUtilization = metricsData["BandwidthPercent"].(float64)
+		findOemFields(metricsData["Oem"], "", acceleratorOemKeys, gpu.OemMetrics)
+	}
+	if environmentURI := getOdataID(processorData, "EnvironmentMetrics"); environmentURI != "" {
+		environmentData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, environmentURI, userAuthData)
+		if temperature, ok := environmentData["TemperatureCelsius"].(map[string]interface{}); ok && !hasTemperature {
+			gpu.TemperatureCelsius, hasTemperature = temperature["Reading"].(float64)
+		}
+		if power, ok := environmentData["PowerWatts"].(map[string]interface{}); ok && !hasPower {
+			gpu.PowerWatts, hasPower = power["Reading"].(float64)
+		}
+	}
+	if !hasTemperature {
+		gpu.TemperatureCelsius, _ = firstOemReading(gpu.OemMetrics, "temp")
+	}
+	if !hasPower {
+		gpu.PowerWatts, _ = firstOemReading(gpu.OemMetrics, "power")
+	}
+	if !hasUtilization {
+		gpu.UtilizationPercent, _ = firstOemReading(gpu.OemMetrics, "util")
+	}
+	return gpu
+}
+
+/* getAcceleratorTelemetry() returns the temperature, power and utilization of the GPU, FPGA, DSP and Accelerator
+ * Processors of every system of the device, a host without accelerators returns an empty list
+ */
+func (s *Server) getAcceleratorTelemetry(deviceIPAddress, authStr string) (accelerators []accelerator, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, systemOdataID := range getMemberOdataIds(deviceIPAddress, RfSystems, userAuthData) {
+		systemData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, systemOdataID, userAuthData)
+		processorsURI := getOdataID(systemData, "Processors")
+		if processorsURI == "" {
+			continue
+		}
+		for _, odataID := range getMemberOdataIds(deviceIPAddress, processorsURI, userAuthData) {
+			processorData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, odataID, userAuthData)
+			if processorType, _ := processorData["ProcessorType"].(string); !acceleratorTypes[processorType] {
+				continue
+			}
+			accelerators = append(accelerators, readAccelerator(deviceIPAddress, odataID, processorData, userAuthData))
+		}
+	}
+	return accelerators, http.StatusOK, nil
+}
@@ -401,6 +401,126 @@ func (s *Server) setSessionService(deviceIPAddress, authStr string, status bool,
 	return statusCode, nil
 }
 
+func (s *Server) getSessionService(deviceIPAddress, authStr string) (enabled bool, sessionTimeout uint64, statusNum int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return false, 0, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	sessionData, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfSessionService, userAuthData)
+	if statusCode != http.StatusOK || sessionData == nil {
+		logrus.Errorf(ErrGetSessionServiceFailed.String(strconv.Itoa(statusCode)))
+		return false, 0, statusCode, errors.New(ErrGetSessionServiceFailed.String(strconv.Itoa(statusCode)))
+	}
+	enabled, _ = sessionData["ServiceEnabled"].(bool)
+	if timeout, ok := sessionData["SessionTimeout"].(float64); ok {
+		sessionTimeout = uint64(timeout)
+	}
+	return enabled, sessionTimeout, statusCode, nil
+}
+
+//accountPolicy holds the password and lockout policies of the AccountService
+type accountPolicy struct {
+	MinPasswordLength               uint32
+	MaxPasswordLength               uint32
+	AccountLockoutThreshold         uint32
+	AccountLockoutDuration          uint32
+	AccountLockoutCounterResetAfter uint32
+	NotReported                     []string
+}
+
+/* getAccountServicePolicy() reads the password length and account lockout policies of the AccountService,
+ * the properties the device does not report are listed in NotReported so they are not mistaken for a 0 policy
+ */
+func (s *Server) getAccountServicePolicy(deviceIPAddress, authStr string) (policy accountPolicy, statusNum int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return policy, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	accountData, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfAccountsService, userAuthData)
+	if statusCode != http.StatusOK || accountData == nil {
+		logrus.Errorf(ErrGetAccountServiceFailed.String(strconv.Itoa(statusCode)))
+		return policy, statusCode, errors.New(ErrGetAccountServiceFailed.String(strconv.Itoa(statusCode)))
+	}
+	properties := []struct {
+		name  string
+		value *uint32
+	}{
+		{"MinPasswordLength", &policy.MinPasswordLength},
+		{"MaxPasswordLength", &policy.MaxPasswordLength},
+		{"AccountLockoutThreshold", &policy.AccountLockoutThreshold},
+		{"AccountLockoutDuration", &policy.AccountLockoutDuration},
+		{"AccountLockoutCounterResetAfter", &policy.AccountLockoutCounterResetAfter},
+	}
+	for _, property := range properties {
+		value, ok := accountData[property.name].(float64)
+		if !ok {
+			policy.NotReported = append(policy.NotReported, property.name)
+			continue
+		}
+		*property.value = uint32(value)
+	}
+	return policy, statusCode, nil
+}
+
+//accountPolicySettable lists the AccountService policies setAccountServicePolicy can change
+var accountPolicySettable = []string{"AccountLockoutThreshold", "AccountLockoutDuration", "MinPasswordLength"}
+
+/* setAccountServicePolicy() patches the given lockout and password length policies of the AccountService after
+ * checking the device reports them, MinPasswordLength is kept between 1 and MaxPasswordLength and a non zero
+ * AccountLockoutDuration may not be shorter than AccountLockoutCounterResetAfter, it returns the policy read back
+ */
+func (s *Server) setAccountServicePolicy(deviceIPAddress, authStr string, settings map[string]uint32) (policy accountPolicy, statusNum int, err error) {
+	current, statusCode, err := s.getAccountServicePolicy(deviceIPAddress, authStr)
+	if err != nil {
+		return policy, statusCode, err
+	}
+	accountInfo := map[string]interface{}{}
+	for name, value := range settings {
+		settable := false
+		for _, property := range accountPolicySettable {
+			settable = settable || property == name
+		}
+		for _, property := range current.NotReported {
+			settable = settable && property != name
+		}
+		if !settable {
+			logrus.Errorf(ErrAccountPolicyNotSupported.String(name))
+			return policy, http.StatusBadRequest, errors.New(ErrAccountPolicyNotSupported.String(name))
+		}
+		accountInfo[name] = value
+	}
+	if len(accountInfo) == 0 {
+		return current, http.StatusOK, nil
+	}
+	if minLength, ok := settings["MinPasswordLength"]; ok {
+		maxLength := uint32(PasswordMaxLength)
+		if current.MaxPasswordLength != 0 {
+			maxLength = current.MaxPasswordLength
+		}
+		if minLength < 1 || minLength > maxLength {
+			value := strconv.FormatUint(uint64(minLength), 10)
+			expected := "1-" + strconv.FormatUint(uint64(maxLength), 10)
+			logrus.Errorf(ErrAccountPolicyInvalid.String("MinPasswordLength", value, expected))
+			return policy, http.StatusBadRequest, errors.New(ErrAccountPolicyInvalid.String("MinPasswordLength", value, expected))
+		}
+	}
+	if duration, ok := settings["AccountLockoutDuration"]; ok && duration != 0 && duration < current.AccountLockoutCounterResetAfter {
+		value := strconv.FormatUint(uint64(duration), 10)
+		expected := "0 or at least AccountLockoutCounterResetAfter " + strconv.FormatUint(uint64(current.AccountLockoutCounterResetAfter), 10)
+		logrus.Errorf(ErrAccountPolicyInvalid.String("AccountLockoutDuration", value, expected))
+		return policy, http.StatusBadRequest, errors.New(ErrAccountPolicyInvalid.String("AccountLockoutDuration", value, expected))
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, RfAccountsService, userAuthData, accountInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetAccountServiceFailed.String(strconv.Itoa(statusCode)))
+		return policy, statusCode, errors.New(ErrSetAccountServiceFailed.String(strconv.Itoa(statusCode)))
+	}
+	return s.getAccountServicePolicy(deviceIPAddress, authStr)
+}
+
 func (s *Server) loginDevice(deviceIPAddress, loginUserName, loginPassword string, authType bool) (RetToken string, statusNum int, err error) {
 	var statusCode int
 	defer func() {
@@ -0,0 +1,139 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//actionParameter holds a parameter of a Redfish action with the values the device accepts for it
+type actionParameter struct {
+	Name            string
+	AllowableValues []string
+}
+
+//allowedAction holds a Redfish action found on a resource of the device and its parameters with allowable values
+type allowedAction struct {
+	Name       string
+	Target     string
+	Parameters []actionParameter
+}
+
+//actionServices lists the services searched for actions besides the members of Systems, Chassis and Managers
+var actionServices = []string{RfUpdateService, RfCertificateService, RfEventService}
+
+/* matchActionName() reports whether the Actions key (e.g. "#ComputerSystem.Reset") is the requested action, given
+ * with or without the leading '#' or as the bare action name (e.g. "Reset"), compared case insensitively
+ */
+func matchActionName(key, action string) bool {
+	key = strings.ToLower(strings.TrimPrefix(key, "#"))
+	action = strings.ToLower(strings.TrimPrefix(action, "#"))
+	return key == action || strings.HasSuffix(key, "."+action)
+}
+
+/* actionParameters() collects the "<parameter>@Redfish.AllowableValues" annotations of the action and the
+ * AllowableValues of the parameters of its @Redfish.ActionInfo resource, sorted by parameter name
+ */
+func actionParameters(deviceIPAddress string, userAuthData userAuth, actionData map[string]interface{}) (parameters []actionParameter) {
+	allowed := map[string][]string{}
+	for key := range actionData {
+		if name := strings.TrimSuffix(key, "@Redfish.AllowableValues"); name != key {
+			allowed[name] = getAllowableValues(actionData, name)
+		}
+	}
+	if actionInfoID, ok := actionData["@Redfish.ActionInfo"].(string); ok {
+		actionInfo, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, actionInfoID, userAuthData)
+		infoParameters, _ := actionInfo["Parameters"].([]interface{})
+		for _, infoParameter := range infoParameters {
+			parameter, _ := infoParameter.(map[string]interface{})
+			name, _ := parameter["Name"].(string)
+			values, _ := parameter["AllowableValues"].([]interface{})
+			if name == "" || len(allowed[name]) != 0 {
+				continue
+			}
+			allowed[name] = []string{}
+			for _, value := range values {
+				if option, ok := value.(string); ok {
+					allowed[name] = append(allowed[name], option)
+				}
+			}
+		}
+	}
+	for name, values := range allowed {
+		parameters = append(parameters, actionParameter{Name: name, AllowableValues: values})
+	}
+	sort.Slice(parameters, func(i, j int) bool { return parameters[i].Name < parameters[j].Name })
+	return parameters
+}
+
+/* getActionAllowableValues() finds the action on the members of Systems, Chassis and Managers and on the
+ * services of the device, including their OEM actions, and returns every match with its target and the allowable
+ * values of its parameters
+ */
+func (s *Server) getActionAllowableValues(deviceIPAddress, authStr, action string) (actions []allowedAction, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	resources := []string{}
+	for _, collection := range []string{RfSystems, RfChassis, RfManager} {
+		odataIds, _, _ := s.getDeviceData(deviceIPAddress, collection, authStr, 2, "@odata.id")
+		resources = append(resources, odataIds...)
+	}
+	resources = append(resources, actionServices...)
+	for _, resource := range resources {
+		resourceData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, resource, userAuthData)
+		resourceActions, _ := resourceData["Actions"].(map[string]interface{})
+		oemActions := map[string]interface{}{}
+		if oem, ok := resourceActions["Oem"].(map[string]interface{}); ok {
+			for key, value := range oem {
+				oemActions[key] = value
+			}
+		}
+		for _, candidates := range []map[string]interface{}{resourceActions, oemActions} {
+			for key, value := range candidates {
+				actionData, ok := value.(map[string]interface{})
+				if !ok || key == "Oem" || !matchActionName(key, action) {
+					continue
+				}
+				target, _ := actionData["target"].(string)
+				actions = append(actions, allowedAction{
+					Name:       strings.TrimPrefix(key, "#"),
+					Target:     target,
+					Parameters: actionParameters(deviceIPAddress, userAuthData, actionData),
+				})
+			}
+		}
+	}
+	if len(actions) == 0 {
+		logrus.Errorf(ErrActionNotFound.String(action))
+		return nil, http.StatusNotFound, errors.New(ErrActionNotFound.String(action))
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].Target < actions[j].Target })
+	return actions, http.StatusOK, nil
+}
@@ -0,0 +1,149 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//biosImport holds the outcome of applying a BIOS attribute set to a device
+type biosImport struct {
+	SettingsOdataID   string
+	AttributeRegistry string
+	Staged            []string
+	Unchanged         int
+	Rejected          []string
+}
+
+/* getBiosResource() returns the Bios resource of the first system of the device
+ */
+func (s *Server) getBiosResource(deviceIPAddress string, userAuthData userAuth) (biosData map[string]interface{}, statusCode int, err error) {
+	_, systemData := s.getFirstSystem(deviceIPAddress, userAuthData)
+	if systemData == nil {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return nil, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	biosOdataID := getOdataID(systemData, "Bios")
+	if biosOdataID == "" {
+		logrus.Errorf(ErrBiosNotFound.String())
+		return nil, http.StatusNotImplemented, errors.New(ErrBiosNotFound.String())
+	}
+	biosData, statusCode, _ = getHTTPBodyDataByRfAPI(deviceIPAddress, biosOdataID, userAuthData)
+	if statusCode != http.StatusOK || biosData == nil {
+		logrus.Errorf(ErrGetDeviceData.String(strconv.Itoa(statusCode)))
+		return nil, statusCode, errors.New(ErrGetDeviceData.String(strconv.Itoa(statusCode)))
+	}
+	return biosData, statusCode, nil
+}
+
+/* exportBiosSettings() returns the current Bios attributes of the first system of the device as a JSON object
+ * with the attribute registry they belong to
+ */
+func (s *Server) exportBiosSettings(deviceIPAddress, authStr string) (attributes, attributeRegistry string, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return "", "", http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	biosData, statusCode, err := s.getBiosResource(deviceIPAddress, userAuthData)
+	if err != nil {
+		return "", "", statusCode, err
+	}
+	attributeRegistry, _ = biosData["AttributeRegistry"].(string)
+	attributesData, err := json.Marshal(biosData["Attributes"])
+	if err != nil {
+		return "", "", http.StatusInternalServerError, errors.New(ErrHTTPDataUpdateFailed.String())
+	}
+	return string(attributesData), attributeRegistry, statusCode, nil
+}
+
+/* importBiosSettings() stages the Bios attributes through the settings object of the Bios resource
+ * (@Redfish.Settings), or the Bios resource itself on a device applying them directly, the change takes effect
+ * at the next reset. Attributes the target does not have are rejected before the PATCH, attributes already set
+ * are skipped and the ones the settings object does not hold afterwards are reported as rejected by the device,
+ * a device without settings object is not read back since it may only show the values after the reset.
+ */
+func (s *Server) importBiosSettings(deviceIPAddress, authStr, attributes string) (result biosImport, statusCode int, err error) {
+	wanted := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(attributes), &wanted); err != nil {
+		logrus.Errorf(ErrBiosDataInvalid.String(err.Error()))
+		return result, http.StatusBadRequest, errors.New(ErrBiosDataInvalid.String(err.Error()))
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return result, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	biosData, statusCode, err := s.getBiosResource(deviceIPAddress, userAuthData)
+	if err != nil {
+		return result, statusCode, err
+	}
+	result.AttributeRegistry, _ = biosData["AttributeRegistry"].(string)
+	result.SettingsOdataID, _ = biosData["@odata.id"].(string)
+	if settings, ok := biosData["@Redfish.Settings"].(map[string]interface{}); ok {
+		if settingsOdataID := getOdataID(settings, "SettingsObject"); settingsOdataID != "" {
+			result.SettingsOdataID = settingsOdataID
+		}
+	}
+	current, _ := biosData["Attributes"].(map[string]interface{})
+	changes := map[string]interface{}{}
+	for name, value := range wanted {
+		currentValue, ok := current[name]
+		switch {
+		case !ok:
+			result.Rejected = append(result.Rejected, name+": not present on target")
+		case reflect.DeepEqual(currentValue, value):
+			result.Unchanged++
+		default:
+			changes[name] = value
+		}
+	}
+	if len(changes) != 0 {
+		_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, result.SettingsOdataID, userAuthData, map[string]interface{}{"Attributes": changes})
+		if statusCode != http.StatusOK && statusCode != http.StatusAccepted && statusCode != http.StatusNoContent {
+			logrus.Errorf(ErrSetBiosFailed.String(strconv.Itoa(statusCode)))
+			return result, statusCode, errors.New(ErrSetBiosFailed.String(strconv.Itoa(statusCode)))
+		}
+		var staged map[string]interface{}
+		if result.SettingsOdataID != biosData["@odata.id"] {
+			settingsData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, result.SettingsOdataID, userAuthData)
+			staged, _ = settingsData["Attributes"].(map[string]interface{})
+		}
+		for name, value := range changes {
+			if stagedValue, ok := staged[name]; staged != nil && (!ok || !reflect.DeepEqual(stagedValue, value)) {
+				result.Rejected = append(result.Rejected, name+": not accepted by device")
+				continue
+			}
+			result.Staged = append(result.Staged, name)
+		}
+	}
+	sort.Strings(result.Staged)
+	sort.Strings(result.Rejected)
+	return result, http.StatusOK, nil
+}
@@ -0,0 +1,205 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+const (
+	//RfCertificateService ...
+	RfCertificateService = "/redfish/v1/CertificateService/"
+	//RfCertificateLocations ...
+	RfCertificateLocations = "/redfish/v1/CertificateService/CertificateLocations/"
+	//RfReplaceCertificate ...
+	RfReplaceCertificate = "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+	//RfCertExpiryCheckInterval is the period between two certificate expiry checks of a polled device
+	RfCertExpiryCheckInterval = 24 * time.Hour
+)
+
+//certificateInfo holds the fields of a Redfish Certificate resource
+type certificateInfo struct {
+	OdataID        string
+	Subject        string
+	Issuer         string
+	ValidNotBefore string
+	ValidNotAfter  string
+}
+
+func getOdataID(data map[string]interface{}, key string) string {
+	link, _ := data[key].(map[string]interface{})
+	odataID, _ := link["@odata.id"].(string)
+	return odataID
+}
+
+/* findManagerCertificateURI() returns the first HTTPS certificate of the manager network protocol,
+ * or the first certificate of the CertificateService locations when the manager does not link it
+ */
+func (s *Server) findManagerCertificateURI(deviceIPAddress string, userAuthData userAuth) string {
+	managers, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfManager, userAuthData)
+	managerOdataIds := s.getRedfishDeviceData(managers, 2, "@odata.id")
+	if len(managerOdataIds) != 0 {
+		managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData)
+		if networkProtocolURI := getOdataID(managerData, "NetworkProtocol"); networkProtocolURI != "" {
+			networkProtocol, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, networkProtocolURI, userAuthData)
+			https, _ := networkProtocol["HTTPS"].(map[string]interface{})
+			if certificatesURI := getOdataID(https, "Certificates"); certificatesURI != "" {
+				certificates, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, certificatesURI, userAuthData)
+				members, _ := certificates["Members"].([]interface{})
+				for _, member := range members {
+					if odataID, _ := member.(map[string]interface{})["@odata.id"].(string); odataID != "" {
+						return odataID
+					}
+				}
+			}
+		}
+	}
+	locations, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfCertificateLocations, userAuthData)
+	links, _ := locations["Links"].(map[string]interface{})
+	certificates, _ := links["Certificates"].([]interface{})
+	for _, certificate := range certificates {
+		if odataID, _ := certificate.(map[string]interface{})["@odata.id"].(string); odataID != "" {
+			return odataID
+		}
+	}
+	return ""
+}
+
+/* getManagerCertificate() returns the subject, issuer and validity of the device HTTPS certificate
+ */
+func (s *Server) getManagerCertificate(deviceIPAddress, authStr string) (certificate certificateInfo, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return certificate, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	return s.readManagerCertificate(deviceIPAddress, userAuthData)
+}
+
+func (s *Server) readManagerCertificate(deviceIPAddress string, userAuthData userAuth) (certificate certificateInfo, statusCode int, err error) {
+	certificateURI := s.findManagerCertificateURI(deviceIPAddress, userAuthData)
+	if certificateURI == "" {
+		logrus.Errorf(ErrCertificateNotFound.String())
+		return certificate, http.StatusNotFound, errors.New(ErrCertificateNotFound.String())
+	}
+	certificateData, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, certificateURI, userAuthData)
+	if certificateData == nil {
+		logrus.Errorf(ErrCertificateNotFound.String())
+		return certificate, http.StatusNotFound, errors.New(ErrCertificateNotFound.String())
+	}
+	certificate.OdataID = certificateURI
+	subject, _ := certificateData["Subject"].(map[string]interface{})
+	certificate.Subject, _ = subject["CommonName"].(string)
+	issuer, _ := certificateData["Issuer"].(map[string]interface{})
+	certificate.Issuer, _ = issuer["CommonName"].(string)
+	certificate.ValidNotBefore, _ = certificateData["ValidNotBefore"].(string)
+	certificate.ValidNotAfter, _ = certificateData["ValidNotAfter"].(string)
+	return certificate, statusCode, nil
+}
+
+/* replaceManagerCertificate() replaces the device HTTPS certificate with a PEM certificate and private key
+ * through the CertificateService ReplaceCertificate action
+ */
+func (s *Server) replaceManagerCertificate(deviceIPAddress, authStr, certificateString string) (statusCode int, err error) {
+	if len(certificateString) == 0 {
+		logrus.Errorf(ErrCertificateEmpty.String())
+		return http.StatusBadRequest, errors.New(ErrCertificateEmpty.String())
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	certificateService, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfCertificateService, userAuthData)
+	actions, _ := certificateService["Actions"].(map[string]interface{})
+	replaceCertificate, ok := actions["#CertificateService.ReplaceCertificate"].(map[string]interface{})
+	if !ok {
+		logrus.Errorf(ErrReplaceCertificateNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrReplaceCertificateNotSupported.String())
+	}
+	target, _ := replaceCertificate["target"].(string)
+	if target == "" {
+		target = RfReplaceCertificate
+	}
+	certificateURI := s.findManagerCertificateURI(deviceIPAddress, userAuthData)
+	if certificateURI == "" {
+		logrus.Errorf(ErrCertificateNotFound.String())
+		return http.StatusNotFound, errors.New(ErrCertificateNotFound.String())
+	}
+	replaceInfo := map[string]interface{}{}
+	replaceInfo["CertificateUri"] = map[string]interface{}{"@odata.id": certificateURI}
+	replaceInfo["CertificateString"] = certificateString
+	replaceInfo["CertificateType"] = "PEM"
+	_, _, statusCode, _ = postHTTPDataByRfAPI(deviceIPAddress, target, userAuthData, replaceInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusAccepted && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrReplaceCertificateFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrReplaceCertificateFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
+
+/* checkCertificateExpiry() reads the device HTTPS certificate once per RfCertExpiryCheckInterval and publishes
+ * a CertificateExpiring event to the device topic when it expires within GlobalConfig.CertExpiryWarningDays. A
+ * failed read is retried in the next polling cycle
+ */
+func (s *Server) checkCertificateExpiry(deviceIPAddress string, userAuthData userAuth) {
+	dev := s.devicemap[deviceIPAddress]
+	if dev == nil || GlobalConfig.CertExpiryWarningDays <= 0 || time.Since(dev.CertCheckedAt) < RfCertExpiryCheckInterval {
+		return
+	}
+	certificate, _, err := s.readManagerCertificate(deviceIPAddress, userAuthData)
+	if err != nil {
+		return
+	}
+	dev.CertCheckedAt = time.Now()
+	notAfter, err := time.Parse(time.RFC3339, certificate.ValidNotAfter)
+	if err != nil {
+		logrus.Errorf(ErrCertificateExpiryInvalid.String(certificate.ValidNotAfter))
+		return
+	}
+	daysLeft := int(time.Until(notAfter).Hours() / 24)
+	if daysLeft > GlobalConfig.CertExpiryWarningDays {
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"IP address:port": deviceIPAddress,
+		"ValidNotAfter":   certificate.ValidNotAfter,
+	}).Warn("device certificate is about to expire")
+	event := map[string]interface{}{}
+	event["SchemaVersion"] = SchemaVersion
+	event["EventType"] = "CertificateExpiring"
+	event["Device"] = deviceIPAddress
+	event["CertificateUri"] = certificate.OdataID
+	event["ValidNotAfter"] = certificate.ValidNotAfter
+	event["DaysLeft"] = daysLeft
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	s.publishDeviceMessage(deviceIPAddress, data)
+}
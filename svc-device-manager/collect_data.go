@@ -24,10 +24,10 @@ package main
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/Shopify/sarama"
 	logrus "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/status"
 )
@@ -37,6 +37,14 @@ const (
 	RfDataCollectDummyInterval = 1000
 	//RfDataCollectThreshold ...
 	RfDataCollectThreshold = 1
+	//RfPollFailureThreshold is the number of consecutive failed polling cycles that marks a device degraded
+	RfPollFailureThreshold = 3
+	//RfDegradedPollBackoff is the number of polling cycles skipped between two probes of a degraded device
+	RfDegradedPollBackoff = 10
+	//DeviceStateActive ...
+	DeviceStateActive = "ACTIVE"
+	//DeviceStateDegraded ...
+	DeviceStateDegraded = "DEGRADED"
 )
 
 var (
@@ -113,6 +121,32 @@ func (s *Server) clearPollingRfAPI(deviceIPAddress string) (statusNum int, err e
 	return http.StatusOK, nil
 }
 
+/* setPollingRfAPIList() replaces the polling Redfish API list of the device with rfAPIs, duplicates are dropped and
+ * the list is left unchanged when one of the Redfish APIs is invalid, an empty rfAPIs clears the list
+ */
+func (s *Server) setPollingRfAPIList(deviceIPAddress, authStr string, rfAPIs []string) (list []string, statusNum int, err error) {
+	list = []string{}
+	seen := map[string]bool{}
+	for _, rfAPI := range rfAPIs {
+		if len(rfAPI) == 0 {
+			continue
+		}
+		rfAPI = addSlashToTail(rfAPI)
+		if seen[rfAPI] {
+			continue
+		}
+		odata, _, _ := s.getDeviceData(deviceIPAddress, rfAPI, authStr, 1, "@odata.id")
+		if odata == nil {
+			logrus.Errorf(ErrRfAPIInvalid.String() + ": " + rfAPI)
+			return nil, http.StatusBadRequest, errors.New(ErrRfAPIInvalid.String() + ": " + rfAPI)
+		}
+		seen[rfAPI] = true
+		list = append(list, rfAPI)
+	}
+	s.devicemap[deviceIPAddress].RfAPIList = list
+	return list, http.StatusOK, nil
+}
+
 func (s *Server) getRfAPIList(deviceIPAddress string) (list []string, statusNum int, err error) {
 	if len(s.devicemap) == 0 {
 		logrus.Errorf(ErrNoDevice.String())
@@ -137,31 +171,12 @@ func (s *Server) collectData(ipAddress string) {
 			logrus.Errorf("Failed to produce message:%s", err)
 		case <-ticker.C:
 			if s.devicemap[ipAddress].QueryState == true {
-				for _, resource := range s.devicemap[ipAddress].RfAPIList {
-					userAuthData := s.devicemap[ipAddress].QueryUser
-					if _, ipErr := s.getFunctionsResult("checkIPAddress", ipAddress, "", ""); ipErr != nil {
-						continue
-					}
-					data, err := s.getDeviceDataByResource(ipAddress, resource, userAuthData)
-					if data != nil && err == nil {
-						for index, str := range data {
-							str = strings.Replace(str, "\n", "", -1)
-							str = strings.Replace(str, " ", "", -1)
-							data[index] = str
-							//str = "Device IP: " + ipAddress + " " + str
-							//logrus.Infof("collected data  %s", str)
-							logrus.Infof("collected data Device IP: %s %s ", ipAddress, str)
-							b := []byte(str)
-							if strings.Contains(ipAddress, ":") {
-								splits := strings.Split(ipAddress, ":")
-								ip, port := splits[0], splits[1]
-								ipAddr := ip + "-" + port
-								msg := &sarama.ProducerMessage{Topic: managerTopic + "-" + ipAddr, Value: sarama.StringEncoder(b)}
-								s.dataproducer.Input() <- msg
-							}
-						}
-					}
+				if s.skipDegradedPoll(ipAddress) {
+					break
 				}
+				s.pollRfAPIs(ipAddress, s.devicemap[ipAddress].QueryUser, getPollTimeout(ipAddress))
+				s.checkCertificateExpiry(ipAddress, s.devicemap[ipAddress].QueryUser)
+				s.reconcileSubscriptions(ipAddress, s.devicemap[ipAddress].QueryUser)
 			}
 		case <-donechan:
 			ticker.Stop()
@@ -172,6 +187,140 @@ func (s *Server) collectData(ipAddress string) {
 	}
 }
 
+/* pollRfAPIs() reads every polling Redfish API of the device, caches and publishes the data, samples the power
+ * consumption of the Power resources read and records the result of the cycle, it returns the number of Redfish
+ * APIs read and the last error met. A GET taking longer than the timeout fails, 0 means no limit
+ */
+func (s *Server) pollRfAPIs(ipAddress string, userAuthData userAuth, timeout time.Duration) (polled int, pollErr error) {
+	polledData := []string{}
+	for _, resource := range s.devicemap[ipAddress].RfAPIList {
+		if _, ipErr := s.getFunctionsResult("checkIPAddress", ipAddress, "", ""); ipErr != nil {
+			pollErr = ipErr
+			continue
+		}
+		data, err := s.getDeviceDataByResource(ipAddress, resource, userAuthData, timeout)
+		if err != nil {
+			pollErr = errors.New(resource + ": " + err.Error())
+		}
+		if data != nil && err == nil {
+			polled++
+			s.cachePolledData(ipAddress, resource, data[0])
+			polledData = append(polledData, data[0])
+			for index, str := range data {
+				str = strings.Replace(str, "\n", "", -1)
+				str = strings.Replace(str, " ", "", -1)
+				data[index] = str
+				//str = "Device IP: " + ipAddress + " " + str
+				//logrus.Infof("collected data  %s", str)
+				logrus.Infof("collected data Device IP: %s %s ", ipAddress, str)
+				s.publishDeviceMessage(ipAddress, []byte(str))
+			}
+		}
+	}
+	s.recordPowerSample(ipAddress, polledData)
+	if len(s.devicemap[ipAddress].RfAPIList) != 0 {
+		s.recordPollResult(ipAddress, polled != 0, pollErr)
+	}
+	return polled, pollErr
+}
+
+//pollNowResult holds how many polling Redfish APIs of the device pollNow read and the last error met
+type pollNowResult struct {
+	Polled    int
+	Total     int
+	LastError string
+}
+
+/* pollNow() polls the Redfish APIs of the device at once with the user of the request, outside of its polling
+ * period, it fails when none of them could be read
+ */
+func (s *Server) pollNow(deviceIPAddress, authStr string) (result pollNowResult, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return result, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	result.Total = len(s.devicemap[deviceIPAddress].RfAPIList)
+	if result.Total == 0 {
+		logrus.Errorf(ErrNoRfAPIToPoll.String())
+		return result, http.StatusBadRequest, errors.New(ErrNoRfAPIToPoll.String())
+	}
+	polled, pollErr := s.pollRfAPIs(deviceIPAddress, userAuthData, 0)
+	result.Polled = polled
+	if pollErr != nil {
+		result.LastError = pollErr.Error()
+	}
+	if polled == 0 {
+		logrus.Errorf(ErrPollNowFailed.String(result.LastError))
+		return result, http.StatusBadGateway, errors.New(ErrPollNowFailed.String(result.LastError))
+	}
+	return result, http.StatusOK, nil
+}
+
+/* skipDegradedPoll() reports whether the polling cycle of a degraded device is skipped, every
+ * RfDegradedPollBackoff cycles one cycle is let through to probe the device
+ */
+func (s *Server) skipDegradedPoll(deviceIPAddress string) bool {
+	dev := s.devicemap[deviceIPAddress]
+	dev.PollStateLock.Lock()
+	defer dev.PollStateLock.Unlock()
+	if !dev.Degraded {
+		return false
+	}
+	if dev.PollSkips > 0 {
+		dev.PollSkips--
+		return true
+	}
+	dev.PollSkips = RfDegradedPollBackoff
+	return false
+}
+
+/* recordPollResult() counts consecutive failed polling cycles, the device becomes degraded after
+ * RfPollFailureThreshold failures and recovers on the first successful cycle. The time of the cycle and
+ * the last error met in it are kept for GetPollStatus
+ */
+func (s *Server) recordPollResult(deviceIPAddress string, success bool, pollErr error) {
+	dev := s.devicemap[deviceIPAddress]
+	dev.PollStateLock.Lock()
+	defer dev.PollStateLock.Unlock()
+	dev.LastPollAt = time.Now()
+	dev.LastPollError = ""
+	if pollErr != nil {
+		dev.LastPollError = pollErr.Error()
+	}
+	if success {
+		dev.LastPollOKAt = dev.LastPollAt
+		if dev.Degraded {
+			logrus.WithFields(logrus.Fields{
+				"IP address:port": deviceIPAddress}).Info("device recovered, polling resumes at the normal period")
+		}
+		dev.PollFailures = 0
+		dev.PollSkips = 0
+		dev.Degraded = false
+		return
+	}
+	dev.PollFailures++
+	if !dev.Degraded && dev.PollFailures >= RfPollFailureThreshold {
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": deviceIPAddress}).Errorf(ErrDeviceDegraded.String(strconv.Itoa(dev.PollFailures)))
+		dev.Degraded = true
+		dev.PollSkips = RfDegradedPollBackoff
+	}
+}
+
+func (s *Server) getDevicePollState(deviceIPAddress string) string {
+	return devicePollState(s.devicemap[deviceIPAddress])
+}
+
+func devicePollState(dev *device) string {
+	dev.PollStateLock.Lock()
+	defer dev.PollStateLock.Unlock()
+	if dev.Degraded {
+		return DeviceStateDegraded
+	}
+	return DeviceStateActive
+}
+
 func (s *Server) startQueryDeviceData(deviceIPAddress string, authStr string) (statusNum int, err error) {
 	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
 	if (userAuthData == userAuth{}) {
@@ -189,13 +338,44 @@ func (s *Server) stopQueryDeviceData(deviceIPAddress string) (statusNum int, err
 	return http.StatusOK, nil
 }
 
+/* updateAttachedDevice() applies the attach settings to an attached device, the logins, polling Redfish APIs and
+ * cached data of the device are kept
+ */
+func (s *Server) updateAttachedDevice(deviceIPAddress string, frequency uint32, passAuth, skipTLSVerify bool) {
+	d := s.devicemap[deviceIPAddress]
+	s.applyFrequency(deviceIPAddress, frequency)
+	d.UserAuthLock.Lock()
+	d.PassAuth = passAuth
+	d.UserAuthLock.Unlock()
+	setTLSSkipVerify(deviceIPAddress, skipTLSVerify)
+	logrus.WithFields(logrus.Fields{
+		"IP address:port": deviceIPAddress}).Info("device is already attached, attach settings updated")
+}
+
 func (s *Server) setFrequency(deviceIPAddress string, frequency uint32) (statusNum int, err error) {
 	if frequency >= 0 && frequency < RfDataCollectThreshold {
 		logrus.WithFields(logrus.Fields{
 			"IP address:port": deviceIPAddress}).Info(ErrFreqValueInvalid.String())
 		return http.StatusBadRequest, status.Errorf(http.StatusBadRequest, ErrFreqValueInvalid.String())
 	}
-	s.devicemap[deviceIPAddress].Freqchan <- frequency
-	s.devicemap[deviceIPAddress].Freq = frequency
+	s.applyFrequency(deviceIPAddress, frequency)
 	return http.StatusOK, nil
 }
+
+/* applyFrequency() hands a changed polling period to collectData without waiting for a polling cycle in progress,
+ * a period collectData has not picked up yet is replaced, 0 stops polling
+ */
+func (s *Server) applyFrequency(deviceIPAddress string, frequency uint32) {
+	d := s.devicemap[deviceIPAddress]
+	d.FreqLock.Lock()
+	defer d.FreqLock.Unlock()
+	if d.Freq == frequency {
+		return
+	}
+	select {
+	case <-d.Freqchan:
+	default:
+	}
+	d.Freqchan <- frequency
+	d.Freq = frequency
+}
@@ -0,0 +1,79 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+func TestRecordPollResult(t *testing.T) {
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}}
+	for i := 0; i < RfPollFailureThreshold; i++ {
+		if s.skipDegradedPoll("192.168.4.27:8888") {
+			t.Fatalf("cycle %d of an active device was skipped", i)
+		}
+		s.recordPollResult("192.168.4.27:8888", false, errors.New("timeout"))
+	}
+	if state := s.getDevicePollState("192.168.4.27:8888"); state != DeviceStateDegraded {
+		t.Fatalf("state after %d failures = %s, want %s", RfPollFailureThreshold, state, DeviceStateDegraded)
+	}
+	for i := 0; i < RfDegradedPollBackoff; i++ {
+		if !s.skipDegradedPoll("192.168.4.27:8888") {
+			t.Fatalf("cycle %d of the backoff was not skipped", i)
+		}
+	}
+	if s.skipDegradedPoll("192.168.4.27:8888") {
+		t.Fatal("probe cycle after the backoff was skipped")
+	}
+	s.recordPollResult("192.168.4.27:8888", true, nil)
+	if state := s.getDevicePollState("192.168.4.27:8888"); state != DeviceStateActive {
+		t.Fatalf("state after a successful cycle = %s, want %s", state, DeviceStateActive)
+	}
+}
+
+// TestPollStateConcurrentAccess is meant to be run with -race, pollNow and the
+// gRPC readers run alongside the polling loop of the device
+func TestPollStateConcurrentAccess(t *testing.T) {
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if !s.skipDegradedPoll("192.168.4.27:8888") {
+				s.recordPollResult("192.168.4.27:8888", i%5 == 0, errors.New("timeout"))
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			s.getDevicePollState("192.168.4.27:8888")
+			s.getFleetTelemetry()
+			s.writeMetrics(ioutil.Discard)
+		}
+	}()
+	wg.Wait()
+}
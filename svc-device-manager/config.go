@@ -43,15 +43,31 @@ import (
 
 //GlobalConfigSpec  ...
 type GlobalConfigSpec struct {
-	Local     string `yaml:"local"`
-	LocalGrpc string `yaml:"localgrpc"`
+	Local                 string `yaml:"local"`
+	LocalGrpc             string `yaml:"localgrpc"`
+	PowerHistoryDepth     int    `yaml:"powerhistorydepth"`
+	MaxRequestsPerSecond  int    `yaml:"maxrequestspersecond"`
+	DeviceCACert          string `yaml:"devicecacert"`
+	CertExpiryWarningDays int    `yaml:"certexpirywarningdays"`
+	PollTimeout           int    `yaml:"polltimeout"`
+	MaxDevices            int    `yaml:"maxdevices"`
+	AutoResubscribe       bool   `yaml:"autoresubscribe"`
+	OemRules              string `yaml:"oemrules"`
+	LogFormat             string `yaml:"logformat"`
+	MetricsAddress        string `yaml:"metricsaddress"`
 }
 
 //GlobalConfig ...
 var (
 	GlobalConfig = GlobalConfigSpec{
-		Local:     "0.0.0.0:8080",
-		LocalGrpc: "0.0.0.0:50051",
+		Local:                 "0.0.0.0:8080",
+		LocalGrpc:             "0.0.0.0:50051",
+		PowerHistoryDepth:     1440,
+		MaxRequestsPerSecond:  10,
+		CertExpiryWarningDays: 30,
+		MaxDevices:            2000,
+		AutoResubscribe:       true,
+		LogFormat:             "text",
 	}
 	GlobalCommandOptions = make(map[string]map[string]string)
 	GlobalOptions        struct {
@@ -335,6 +351,7 @@ func ProcessGlobalOptions() {
 	if GlobalOptions.LocalGrpc != "" {
 		GlobalConfig.LocalGrpc = GlobalOptions.LocalGrpc
 	}
+	logrus.SetFormatter(newLogFormatter(GlobalConfig.LogFormat))
 }
 
 //ShowGlobalOptions ...
@@ -342,4 +359,14 @@ func ShowGlobalOptions() {
 	log.Printf("Configuration:")
 	log.Printf("    Listen Address: %v", GlobalConfig.Local)
 	log.Printf("    Grpc Listen Address: %v", GlobalConfig.LocalGrpc)
+	log.Printf("    Power History Depth: %v", GlobalConfig.PowerHistoryDepth)
+	log.Printf("    Max Requests Per Second: %v", GlobalConfig.MaxRequestsPerSecond)
+	log.Printf("    Device CA Certificate: %v", GlobalConfig.DeviceCACert)
+	log.Printf("    Certificate Expiry Warning Days: %v", GlobalConfig.CertExpiryWarningDays)
+	log.Printf("    Poll Timeout: %v", GlobalConfig.PollTimeout)
+	log.Printf("    Max Devices: %v", GlobalConfig.MaxDevices)
+	log.Printf("    Auto Resubscribe: %v", GlobalConfig.AutoResubscribe)
+	log.Printf("    OEM Rules: %v", GlobalConfig.OemRules)
+	log.Printf("    Log Format: %v", GlobalConfig.LogFormat)
+	log.Printf("    Metrics Listen Address: %v", GlobalConfig.MetricsAddress)
 }
@@ -0,0 +1,101 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//Conformance states of a probed resource reported to the client
+const (
+	ConformancePresent   = "PRESENT"
+	ConformanceAbsent    = "ABSENT"
+	ConformanceMalformed = "MALFORMED"
+)
+
+//RfServiceRoot ...
+const RfServiceRoot = "/redfish/v1/"
+
+//conformanceResources lists the standard resources probed by the conformance check, true marks a collection
+var conformanceResources = []struct {
+	Name       string
+	URI        string
+	Collection bool
+}{
+	{"ServiceRoot", RfServiceRoot, false},
+	{"Systems", RfSystems, true},
+	{"Chassis", RfChassis, true},
+	{"Managers", RfManager, true},
+	{"EventService", RfEventService, false},
+}
+
+//conformanceResult holds the probe result of one standard resource
+type conformanceResult struct {
+	Name   string
+	URI    string
+	Status string
+	Detail string
+}
+
+/* probeResource() reads a standard resource, it is MALFORMED when the body is not JSON, lacks @odata.id or
+ * @odata.type, or is a collection without a Members array
+ */
+func probeResource(deviceIPAddress, uri string, collection bool, userAuthData userAuth) (resourceStatus, detail string) {
+	data, statusCode, err := getHTTPBodyDataByRfAPI(deviceIPAddress, uri, userAuthData)
+	if statusCode != http.StatusOK {
+		return ConformanceAbsent, "status code " + strconv.Itoa(statusCode)
+	}
+	if err != nil || data == nil {
+		return ConformanceMalformed, "invalid JSON body"
+	}
+	for _, key := range []string{"@odata.id", "@odata.type"} {
+		if _, ok := data[key].(string); !ok {
+			return ConformanceMalformed, "missing " + key
+		}
+	}
+	if collection {
+		if _, ok := data["Members"].([]interface{}); !ok {
+			return ConformanceMalformed, "missing Members"
+		}
+	}
+	return ConformancePresent, ""
+}
+
+/* checkRedfishConformance() probes the standard resources of device to build the capability fingerprint of its model
+ */
+func (s *Server) checkRedfishConformance(deviceIPAddress, authStr string) (results []conformanceResult, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, resource := range conformanceResources {
+		result := conformanceResult{Name: resource.Name, URI: resource.URI}
+		result.Status, result.Detail = probeResource(deviceIPAddress, resource.URI, resource.Collection, userAuthData)
+		results = append(results, result)
+	}
+	return results, http.StatusOK, nil
+}
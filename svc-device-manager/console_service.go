@@ -0,0 +1,131 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//consoleNames maps the console argument of the commands to the Manager property of the console
+var consoleNames = map[string]string{
+	"serial":    "SerialConsole",
+	"graphical": "GraphicalConsole",
+}
+
+//consoleConnection holds a connect type of a console and the port of its ManagerNetworkProtocol entry
+type consoleConnection struct {
+	ConnectType string
+	Port        uint32
+}
+
+//consoleInfo holds the SerialConsole or GraphicalConsole config of a Manager
+type consoleInfo struct {
+	Name                  string
+	ServiceEnabled        bool
+	MaxConcurrentSessions uint32
+	Connections           []consoleConnection
+}
+
+/* getConsoleStatus() reads the SerialConsole and GraphicalConsole of the first manager of the device, the port of each
+ * connect type (SSH, Telnet, IPMI, KVMIP) is taken from the manager NetworkProtocol
+ */
+func (s *Server) getConsoleStatus(deviceIPAddress, authStr string) (consoles []consoleInfo, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	if len(managerOdataIds) == 0 {
+		logrus.Errorf(ErrConsoleNotSupported.String())
+		return nil, http.StatusNotImplemented, errors.New(ErrConsoleNotSupported.String())
+	}
+	managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData)
+	networkProtocol := map[string]interface{}{}
+	if networkProtocolURI := getOdataID(managerData, "NetworkProtocol"); networkProtocolURI != "" {
+		networkProtocol, _, _ = getHTTPBodyDataByRfAPI(deviceIPAddress, networkProtocolURI, userAuthData)
+	}
+	for _, name := range []string{"SerialConsole", "GraphicalConsole"} {
+		consoleData, ok := managerData[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		console := consoleInfo{Name: name}
+		console.ServiceEnabled, _ = consoleData["ServiceEnabled"].(bool)
+		if maxSessions, ok := consoleData["MaxConcurrentSessions"].(float64); ok {
+			console.MaxConcurrentSessions = uint32(maxSessions)
+		}
+		connectTypes, _ := consoleData["ConnectTypesSupported"].([]interface{})
+		for _, connectType := range connectTypes {
+			connection := consoleConnection{}
+			connection.ConnectType, _ = connectType.(string)
+			protocol, _ := networkProtocol[connection.ConnectType].(map[string]interface{})
+			if port, ok := protocol["Port"].(float64); ok {
+				connection.Port = uint32(port)
+			}
+			console.Connections = append(console.Connections, connection)
+		}
+		consoles = append(consoles, console)
+	}
+	if len(consoles) == 0 {
+		logrus.Errorf(ErrConsoleNotSupported.String())
+		return nil, http.StatusNotImplemented, errors.New(ErrConsoleNotSupported.String())
+	}
+	return consoles, http.StatusOK, nil
+}
+
+/* setConsoleEnabled() patches ServiceEnabled of the serial or graphical console of the first manager of the device
+ */
+func (s *Server) setConsoleEnabled(deviceIPAddress, authStr, console string, enabled bool) (statusCode int, err error) {
+	name, ok := consoleNames[strings.ToLower(console)]
+	if !ok {
+		logrus.Errorf(ErrConsoleInvalid.String(console))
+		return http.StatusBadRequest, errors.New(ErrConsoleInvalid.String(console))
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	if len(managerOdataIds) == 0 {
+		logrus.Errorf(ErrConsoleNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrConsoleNotSupported.String())
+	}
+	managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData)
+	if _, ok := managerData[name].(map[string]interface{}); !ok {
+		logrus.Errorf(ErrConsoleNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrConsoleNotSupported.String())
+	}
+	consoleSettings := map[string]interface{}{name: map[string]interface{}{"ServiceEnabled": enabled}}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData, consoleSettings)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetConsoleFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrSetConsoleFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
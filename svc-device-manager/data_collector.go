@@ -31,6 +31,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	logrus "github.com/sirupsen/logrus"
 )
@@ -126,8 +127,8 @@ func parseArray(anarray []interface{}, level uint, levelPos uint, archive map[st
 
 Based on careful examination of the data returned from several resources sampled, it was determined that sub-folder paths can be found as the value to the key '@odata.id' showing up at the 2nd level of the data read from a resource.
 */
-func readDeviceResource(deviceIPAddress, resource string, archive map[string]bool, userAuthData userAuth) (data []string, err error) {
-	body, statusCode, err := getHTTPBodyByRfAPI(deviceIPAddress, resource, userAuthData)
+func readDeviceResource(deviceIPAddress, resource string, archive map[string]bool, userAuthData userAuth, timeout time.Duration) (data []string, err error) {
+	body, statusCode, err := getHTTPBodyByRfAPIWithTimeout(deviceIPAddress, resource, userAuthData, timeout)
 	data = append(data, string(body))
 	if err != nil || body == nil {
 		logrus.Errorf(ErrHTTPGetBody.String(err.Error(), strconv.Itoa(statusCode)))
@@ -151,10 +152,10 @@ func readDeviceResource(deviceIPAddress, resource string, archive map[string]boo
 	return data, err
 }
 
-func (s *Server) getDeviceDataByResource(deviceIPAddress, resource string, userAuthData userAuth) (data []string, err error) {
+func (s *Server) getDeviceDataByResource(deviceIPAddress, resource string, userAuthData userAuth, timeout time.Duration) (data []string, err error) {
 	archive := make(map[string]bool)
 	/* 'archive' maintains a list of all resources that will be/have been visited to avoid duplicates */
-	data, err = readDeviceResource(deviceIPAddress, resource, archive, userAuthData)
+	data, err = readDeviceResource(deviceIPAddress, resource, archive, userAuthData, timeout)
 	return data, err
 }
 
@@ -192,11 +193,18 @@ func (s *Server) getDataFromCache(deviceDataFile *os.File, RfAPI string) (status
 	return http.StatusOK, retData, nil
 }
 
-func (s *Server) getDeviceDataByFileData(deviceIPAddress, RfAPI string) (statusNum int, retData []string, err error) {
-	// TODO: DeviceDataFile was removed due to integration with ODIM's services.
-	// TODO: Replace this code once integration is done.
-	logrus.Errorf(ErrDeviceDataFileNotFound.String(deviceIPAddress))
-	return http.StatusNotFound, retData, errors.New(ErrDeviceDataFileNotFound.String(deviceIPAddress))
+/* getDeviceDataByPolledData() returns the data of the Redfish API kept by the last successful polling cycle
+ */
+func (s *Server) getDeviceDataByPolledData(deviceIPAddress, RfAPI string) (statusNum int, retData []string, err error) {
+	dev := s.devicemap[deviceIPAddress]
+	dev.PolledDataLock.Lock()
+	data, ok := dev.PolledData[RfAPI]
+	dev.PolledDataLock.Unlock()
+	if !ok {
+		logrus.Errorf(ErrPolledDataNotFound.String(RfAPI))
+		return http.StatusNotFound, nil, errors.New(ErrPolledDataNotFound.String(RfAPI))
+	}
+	return http.StatusOK, []string{data}, nil
 }
 
 func (s *Server) genericDeviceAccess(deviceIPAddress, RfAPI, authStr string, httpMethod string,
@@ -0,0 +1,132 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+const (
+	//DiffChanged marks a key present on both devices with different values
+	DiffChanged = "changed"
+	//DiffOnlyFirst marks a key present only on the first device
+	DiffOnlyFirst = "only in first"
+	//DiffOnlySecond marks a key present only on the second device
+	DiffOnlySecond = "only in second"
+)
+
+type jsonDifference struct {
+	Path   string
+	Kind   string
+	Value1 string
+	Value2 string
+}
+
+func jsonValueToString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+/* diffJSON() walks two decoded JSON documents and returns every path whose value differs or exists on one side only
+ */
+func diffJSON(path string, first, second interface{}) (diffs []jsonDifference) {
+	firstMap, firstIsMap := first.(map[string]interface{})
+	secondMap, secondIsMap := second.(map[string]interface{})
+	if firstIsMap && secondIsMap {
+		keys := []string{}
+		for key := range firstMap {
+			keys = append(keys, key)
+		}
+		for key := range secondMap {
+			if _, ok := firstMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			firstValue, inFirst := firstMap[key]
+			secondValue, inSecond := secondMap[key]
+			switch {
+			case !inSecond:
+				diffs = append(diffs, jsonDifference{Path: path + "." + key, Kind: DiffOnlyFirst, Value1: jsonValueToString(firstValue)})
+			case !inFirst:
+				diffs = append(diffs, jsonDifference{Path: path + "." + key, Kind: DiffOnlySecond, Value2: jsonValueToString(secondValue)})
+			default:
+				diffs = append(diffs, diffJSON(path+"."+key, firstValue, secondValue)...)
+			}
+		}
+		return diffs
+	}
+	firstArray, firstIsArray := first.([]interface{})
+	secondArray, secondIsArray := second.([]interface{})
+	if firstIsArray && secondIsArray {
+		for index := 0; index < len(firstArray) || index < len(secondArray); index++ {
+			elementPath := path + "[" + strconv.Itoa(index) + "]"
+			switch {
+			case index >= len(secondArray):
+				diffs = append(diffs, jsonDifference{Path: elementPath, Kind: DiffOnlyFirst, Value1: jsonValueToString(firstArray[index])})
+			case index >= len(firstArray):
+				diffs = append(diffs, jsonDifference{Path: elementPath, Kind: DiffOnlySecond, Value2: jsonValueToString(secondArray[index])})
+			default:
+				diffs = append(diffs, diffJSON(elementPath, firstArray[index], secondArray[index])...)
+			}
+		}
+		return diffs
+	}
+	if !reflect.DeepEqual(first, second) {
+		diffs = append(diffs, jsonDifference{Path: path, Kind: DiffChanged, Value1: jsonValueToString(first), Value2: jsonValueToString(second)})
+	}
+	return diffs
+}
+
+/* diffDevices() reads the same Redfish resource from two devices and returns the differences of their JSON data
+ */
+func (s *Server) diffDevices(firstIPAddress, firstAuthStr, secondIPAddress, secondAuthStr, RfAPI string) (diffs []jsonDifference, statusCode int, err error) {
+	data := []map[string]interface{}{}
+	for _, target := range [][]string{{firstIPAddress, firstAuthStr}, {secondIPAddress, secondAuthStr}} {
+		userAuthData := s.getUserAuthData(target[0], target[1])
+		if (userAuthData == userAuth{}) {
+			logrus.Errorf(ErrUserAuthNotFound.String())
+			return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+		}
+		deviceData, statusCode, _ := getHTTPBodyDataByRfAPI(target[0], RfAPI, userAuthData)
+		if deviceData == nil || statusCode != http.StatusOK {
+			if statusCode == http.StatusOK {
+				statusCode = http.StatusNoContent
+			}
+			logrus.Errorf(ErrGetDiffDataFailed.String(RfAPI, target[0], strconv.Itoa(statusCode)))
+			return nil, statusCode, errors.New(ErrGetDiffDataFailed.String(RfAPI, target[0], strconv.Itoa(statusCode)))
+		}
+		data = append(data, deviceData)
+	}
+	return diffJSON("$", data[0], data[1]), http.StatusOK, nil
+}
@@ -0,0 +1,104 @@
+/*Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+func (s *Server) createGroup(name string) (statusCode int, err error) {
+	if len(name) == 0 {
+		logrus.Errorf(ErrGroupNameEmpty.String())
+		return http.StatusBadRequest, errors.New(ErrGroupNameEmpty.String())
+	}
+	s.groupLock.Lock()
+	defer s.groupLock.Unlock()
+	if s.groups == nil {
+		s.groups = make(map[string][]string)
+	}
+	if _, ok := s.groups[name]; ok {
+		logrus.Errorf(ErrGroupExists.String(name))
+		return http.StatusBadRequest, errors.New(ErrGroupExists.String(name))
+	}
+	s.groups[name] = []string{}
+	return http.StatusOK, nil
+}
+
+/* addDeviceToGroup() appends the registered devices to the group, devices already in the group are skipped
+ */
+func (s *Server) addDeviceToGroup(name string, deviceIPAddresses []string) (statusCode int, err error) {
+	s.groupLock.Lock()
+	defer s.groupLock.Unlock()
+	members, ok := s.groups[name]
+	if !ok {
+		logrus.Errorf(ErrGroupNotFound.String(name))
+		return http.StatusNotFound, errors.New(ErrGroupNotFound.String(name))
+	}
+	for _, deviceIPAddress := range deviceIPAddresses {
+		found := false
+		for _, member := range members {
+			if member == deviceIPAddress {
+				found = true
+				break
+			}
+		}
+		if !found {
+			members = append(members, deviceIPAddress)
+		}
+	}
+	s.groups[name] = members
+	return http.StatusOK, nil
+}
+
+/* removeDeviceFromGroups() takes a detached device out of every group, the groups themselves are kept
+ */
+func (s *Server) removeDeviceFromGroups(deviceIPAddress string) {
+	s.groupLock.Lock()
+	defer s.groupLock.Unlock()
+	for name, members := range s.groups {
+		for i, member := range members {
+			if member == deviceIPAddress {
+				s.groups[name] = append(members[:i], members[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (s *Server) listGroups() (groups map[string][]string, statusCode int, err error) {
+	s.groupLock.Lock()
+	defer s.groupLock.Unlock()
+	if len(s.groups) == 0 {
+		logrus.Errorf(ErrNoGroup.String())
+		return nil, http.StatusNotFound, errors.New(ErrNoGroup.String())
+	}
+	groups = make(map[string][]string)
+	for name, members := range s.groups {
+		groups[name] = append([]string{}, members...)
+		sort.Strings(groups[name])
+	}
+	return groups, http.StatusOK, nil
+}
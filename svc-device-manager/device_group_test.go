@@ -0,0 +1,44 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRemoveDeviceFromGroups(t *testing.T) {
+	s := &Server{}
+	s.createGroup("rack1")
+	s.createGroup("rack2")
+	s.addDeviceToGroup("rack1", []string{"192.168.4.27:8888", "192.168.4.26:8888"})
+	s.addDeviceToGroup("rack2", []string{"192.168.4.27:8888"})
+	s.removeDeviceFromGroups("192.168.4.27:8888")
+	groups, _, err := s.listGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"rack1": {"192.168.4.26:8888"}, "rack2": {}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("groups = %v, want %v", groups, want)
+	}
+}
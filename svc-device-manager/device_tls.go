@@ -0,0 +1,109 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//TLSSkipVerify lists the devices whose certificate is not verified, set only when requested at attach time
+var TLSSkipVerify = make(map[string]bool)
+var tlsSkipVerifyLock sync.RWMutex
+
+var (
+	deviceTransport         *http.Transport
+	insecureDeviceTransport *http.Transport
+	deviceTransportOnce     sync.Once
+)
+
+/* loadDeviceCACert() reads the PEM CA certificates of file into a pool, an unreadable file or one without a PEM
+ * certificate is an error
+ */
+func loadDeviceCACert(file string) (*x509.CertPool, error) {
+	caCert, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.New(ErrDeviceCACertInvalid.String(file, err.Error()))
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, errors.New(ErrDeviceCACertInvalid.String(file, "no PEM certificate found"))
+	}
+	return caPool, nil
+}
+
+/* initDeviceTransports() builds the transport verifying device certificates against GlobalConfig.DeviceCACert,
+ * or the system roots when no CA is configured, and the skip-verify transport
+ */
+func initDeviceTransports() error {
+	tlsConfig := &tls.Config{}
+	if GlobalConfig.DeviceCACert != "" {
+		caPool, err := loadDeviceCACert(GlobalConfig.DeviceCACert)
+		if err != nil {
+			return err
+		}
+		tlsConfig.RootCAs = caPool
+	}
+	deviceTransport = http.DefaultTransport.(*http.Transport).Clone()
+	deviceTransport.TLSClientConfig = tlsConfig
+	insecureDeviceTransport = http.DefaultTransport.(*http.Transport).Clone()
+	insecureDeviceTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return nil
+}
+
+/* loadDeviceTransports() builds the device transports once, main calls it at startup so a configured CA certificate
+ * that cannot be loaded stops the manager instead of leaving devices verified against the system roots
+ */
+func loadDeviceTransports() {
+	deviceTransportOnce.Do(func() {
+		if err := initDeviceTransports(); err != nil {
+			logrus.Fatal(err)
+		}
+	})
+}
+
+func setTLSSkipVerify(deviceIPAddress string, skipVerify bool) {
+	tlsSkipVerifyLock.Lock()
+	defer tlsSkipVerifyLock.Unlock()
+	TLSSkipVerify[deviceIPAddress] = skipVerify
+}
+
+func getDeviceTransport(deviceIPAddress string) *http.Transport {
+	loadDeviceTransports()
+	tlsSkipVerifyLock.RLock()
+	skipVerify := TLSSkipVerify[deviceIPAddress]
+	tlsSkipVerifyLock.RUnlock()
+	if skipVerify {
+		return insecureDeviceTransport
+	}
+	return deviceTransport
+}
+
+func getDeviceClient(deviceIPAddress string) *http.Client {
+	return &http.Client{Transport: getDeviceTransport(deviceIPAddress)}
+}
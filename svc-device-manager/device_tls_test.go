@@ -0,0 +1,68 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDeviceCACert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devicecacert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+	validFile := filepath.Join(dir, "ca.pem")
+	validPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(validFile, validPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	noPEMFile := filepath.Join(dir, "ca.txt")
+	if err := ioutil.WriteFile(noPEMFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{"PEM certificate", validFile, false},
+		{"missing file", filepath.Join(dir, "missing.pem"), true},
+		{"no PEM certificate", noPEMFile, true},
+	}
+	for _, tt := range tests {
+		caPool, err := loadDeviceCACert(tt.file)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: loadDeviceCACert() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if !tt.wantErr && caPool == nil {
+			t.Errorf("%s: loadDeviceCACert() returned no pool", tt.name)
+		}
+	}
+}
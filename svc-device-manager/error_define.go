@@ -115,6 +115,98 @@ const (
 	ErrUserAuthNotFound
 	ErrCollectingNotStarted
 	ErrMissingDeviceIP
+	ErrSyslogTargetInvalid
+	ErrSyslogNotSupported
+	ErrSetSyslogTargetFailed
+	ErrGetSessionServiceFailed
+	ErrResetToDefaultsNotConfirmed
+	ErrResetToDefaultsNotSupported
+	ErrResetToDefaultsFailed
+	ErrGetFirmwareInventoryFailed
+	ErrMultipartPushNotSupported
+	ErrPushSoftwareFailed
+	ErrApplyTimeNotsupport
+	ErrCancelUpdateFailed
+	ErrGroupNameEmpty
+	ErrGroupExists
+	ErrGroupNotFound
+	ErrNoGroup
+	ErrDeviceDegraded
+	ErrDateTimeNotSupported
+	ErrDateTimeInvalid
+	ErrDateTimeOffsetInvalid
+	ErrSetDateTimeFailed
+	ErrJSONPathInvalid
+	ErrJSONPathNoMatch
+	ErrGetDiffDataFailed
+	ErrDeviceCACertInvalid
+	ErrCertificateNotFound
+	ErrCertificateEmpty
+	ErrReplaceCertificateNotSupported
+	ErrReplaceCertificateFailed
+	ErrCertificateExpiryInvalid
+	ErrGetHealthFailed
+	ErrSystemNotFound
+	ErrBootPolicyEmpty
+	ErrBootPolicyNotSupport
+	ErrSetBootPolicyFailed
+	ErrPortStatsNotSupported
+	ErrSchemaVersionNotSupported
+	ErrThermalRedundancyNotSupported
+	ErrPollTimeoutInvalid
+	ErrPowerCapNotSupported
+	ErrPowerCapOutOfRange
+	ErrSetPowerCapFailed
+	ErrMaxDevicesReached
+	ErrPowerRedundancyNotSupported
+	ErrEventServiceNotSupported
+	ErrEventServiceDisabled
+	ErrSetEventServiceFailed
+	ErrBootProgressNotSupported
+	ErrPCIeDevicesNotSupported
+	ErrConsoleNotSupported
+	ErrConsoleInvalid
+	ErrSetConsoleFailed
+	ErrManufacturingInfoNotSupported
+	ErrIpmiNotSupported
+	ErrSetIpmiFailed
+	ErrMaintenanceWindowInvalid
+	ErrLocationNotSupported
+	ErrOemRulesInvalid
+	ErrDeleteSubscriptionFailed
+	ErrWatchdogNotSupported
+	ErrWatchdogActionNotSupport
+	ErrWatchdogTimeoutNotSupported
+	ErrSetWatchdogFailed
+	ErrNoRfAPIToPoll
+	ErrPollNowFailed
+	ErrFanControlNotSupported
+	ErrFanControlEmpty
+	ErrFanPwmInvalid
+	ErrFanModeNotSupported
+	ErrFanModeNotSupport
+	ErrFanPwmNotSupported
+	ErrSetFanControlFailed
+	ErrBootOverrideNotSupported
+	ErrClearBootOverrideFailed
+	ErrGetAccountServiceFailed
+	ErrAccountPolicyNotSupported
+	ErrAccountPolicyInvalid
+	ErrSetAccountServiceFailed
+	ErrActionNotFound
+	ErrBiosNotFound
+	ErrBiosDataInvalid
+	ErrSetBiosFailed
+	ErrGetUpdateServiceFailed
+	ErrManagerFirmwareNotFound
+	ErrSingleFirmwareBank
+	ErrSwitchFirmwareBankFailed
+	ErrHostNameNotSupported
+	ErrHostNameInvalid
+	ErrSetHostNameFailed
+	ErrBootOrderNotSupported
+	ErrPolledDataNotFound
+	ErrActiveFirmwareBankUnknown
 )
 
 // String - Creating error descriptions - give the type a String function
@@ -215,6 +307,98 @@ func (e errorIndex) String(args ...string) string {
 		/*ErrUserAuthNotFound*/ "The user authentication data does not found",
 		/*ErrCollectingNotStarted*/ "The collecting data has not started yet",
 		/*ErrMissingDeviceIP*/ "Device ip address is missing",
+		/*ErrSyslogTargetInvalid*/ "The syslog target host or port is invalid",
+		/*ErrSyslogNotSupported*/ "Device does not support syslog forwarding",
+		/*ErrSetSyslogTargetFailed*/ "Failed to set syslog target, status code " + argsStrs[0],
+		/*ErrGetSessionServiceFailed*/ "Failed to get session service, status code " + argsStrs[0],
+		/*ErrResetToDefaultsNotConfirmed*/ "The reset to defaults request must be confirmed",
+		/*ErrResetToDefaultsNotSupported*/ "Device does not support resetting the manager to defaults",
+		/*ErrResetToDefaultsFailed*/ "Failed to reset the manager to defaults, status code " + argsStrs[0],
+		/*ErrGetFirmwareInventoryFailed*/ "Failed to get device firmware inventory",
+		/*ErrMultipartPushNotSupported*/ "Device does not support multipart HTTP push updates",
+		/*ErrPushSoftwareFailed*/ "Failed to push software image, status code " + argsStrs[0],
+		/*ErrApplyTimeNotsupport*/ "The apply time (" + argsStrs[0] + ") does not support, The supported apply time are: " + argsStrs[1],
+		/*ErrCancelUpdateFailed*/ "Failed to cancel the software update task, status code " + argsStrs[0],
+		/*ErrGroupNameEmpty*/ "The group name is empty",
+		/*ErrGroupExists*/ "The group " + argsStrs[0] + " already exists",
+		/*ErrGroupNotFound*/ "The group " + argsStrs[0] + " does not exist",
+		/*ErrNoGroup*/ "No group has been created",
+		/*ErrDeviceDegraded*/ "Device failed " + argsStrs[0] + " consecutive polling cycles, polling backs off",
+		/*ErrDateTimeNotSupported*/ "Device does not report a Manager DateTime",
+		/*ErrDateTimeInvalid*/ "The date time " + argsStrs[0] + " is not in RFC 3339 format",
+		/*ErrDateTimeOffsetInvalid*/ "The local offset " + argsStrs[0] + " is not in +HH:MM format",
+		/*ErrSetDateTimeFailed*/ "Failed to set device date time, status code: " + argsStrs[0],
+		/*ErrJSONPathInvalid*/ "The JSON path " + argsStrs[0] + " is invalid",
+		/*ErrJSONPathNoMatch*/ "The JSON path " + argsStrs[0] + " matches nothing",
+		/*ErrGetDiffDataFailed*/ "Failed to get " + argsStrs[0] + " from " + argsStrs[1] + ", status code: " + argsStrs[2],
+		/*ErrDeviceCACertInvalid*/ "Failed to load device CA certificate " + argsStrs[0] + ": " + argsStrs[1],
+		/*ErrCertificateNotFound*/ "Device does not report an HTTPS certificate",
+		/*ErrCertificateEmpty*/ "The certificate is empty",
+		/*ErrReplaceCertificateNotSupported*/ "Device does not support the CertificateService ReplaceCertificate action",
+		/*ErrReplaceCertificateFailed*/ "Failed to replace the certificate, status code: " + argsStrs[0],
+		/*ErrCertificateExpiryInvalid*/ "The certificate expiry " + argsStrs[0] + " is not in RFC 3339 format",
+		/*ErrGetHealthFailed*/ "Failed to get the health of the device resources",
+		/*ErrSystemNotFound*/ "Device does not report a ComputerSystem",
+		/*ErrBootPolicyEmpty*/ "Neither automatic retry config nor power restore policy is given",
+		/*ErrBootPolicyNotSupport*/ "The " + argsStrs[0] + " value " + argsStrs[1] + " is not supported, supported values: " + argsStrs[2],
+		/*ErrSetBootPolicyFailed*/ "Failed to set boot policy, status code: " + argsStrs[0],
+		/*ErrPortStatsNotSupported*/ "Device does not expose network port metrics",
+		/*ErrSchemaVersionNotSupported*/ "The schema version " + argsStrs[0] + " is not supported, supported version: " + argsStrs[1],
+		/*ErrThermalRedundancyNotSupported*/ "Device does not report fan/thermal redundancy",
+		/*ErrPollTimeoutInvalid*/ "The poll timeout has to be between 0 and " + argsStrs[0] + " seconds",
+		/*ErrPowerCapNotSupported*/ "Device does not support power capping",
+		/*ErrPowerCapOutOfRange*/ "The power cap " + argsStrs[0] + "W is out of the device range " + argsStrs[1] + "W to " + argsStrs[2] + "W",
+		/*ErrSetPowerCapFailed*/ "Failed to set power cap, status code: " + argsStrs[0],
+		/*ErrMaxDevicesReached*/ "The device limit is reached, " + argsStrs[0] + " of " + argsStrs[1] + " devices attached",
+		/*ErrPowerRedundancyNotSupported*/ "Device does not report power redundancy or power supplies",
+		/*ErrEventServiceNotSupported*/ "Device does not support the Redfish event service",
+		/*ErrEventServiceDisabled*/ "The device event service is disabled, enable it with seteventservice before subscribing",
+		/*ErrSetEventServiceFailed*/ "Failed to set event service, status code: " + argsStrs[0],
+		/*ErrBootProgressNotSupported*/ "Device does not report boot progress",
+		/*ErrPCIeDevicesNotSupported*/ "Device does not report PCIe devices",
+		/*ErrConsoleNotSupported*/ "Device does not report a serial or graphical console",
+		/*ErrConsoleInvalid*/ "Invalid console " + argsStrs[0] + ", expected serial or graphical",
+		/*ErrSetConsoleFailed*/ "Failed to set console, status code: " + argsStrs[0],
+		/*ErrManufacturingInfoNotSupported*/ "Device does not report a chassis or system",
+		/*ErrIpmiNotSupported*/ "Device does not support IPMI-over-LAN settings",
+		/*ErrSetIpmiFailed*/ "Failed to set IPMI-over-LAN, status code: " + argsStrs[0],
+		/*ErrMaintenanceWindowInvalid*/ "Invalid maintenance window " + argsStrs[0] + " to " + argsStrs[1] + ", the end must be after the start and in the future",
+		/*ErrLocationNotSupported*/ "Device does not report the Location of a chassis",
+		/*ErrOemRulesInvalid*/ "Failed to load OEM rules " + argsStrs[0] + ": " + argsStrs[1],
+		/*ErrDeleteSubscriptionFailed*/ "Failed to delete event subscription, status code: " + argsStrs[0],
+		/*ErrWatchdogNotSupported*/ "Device does not report a host watchdog timer",
+		/*ErrWatchdogActionNotSupport*/ "The watchdog timeout action " + argsStrs[0] + " is not supported, allowable values: " + argsStrs[1],
+		/*ErrWatchdogTimeoutNotSupported*/ "Device does not expose the watchdog timeout",
+		/*ErrSetWatchdogFailed*/ "Failed to set watchdog, status code: " + argsStrs[0],
+		/*ErrNoRfAPIToPoll*/ "The device has no polling Redfish API, add one with AddPollingRfAPI",
+		/*ErrPollNowFailed*/ "Failed to poll the device: " + argsStrs[0],
+		/*ErrFanControlNotSupported*/ "Device reports neither fans nor an OEM fan control",
+		/*ErrFanControlEmpty*/ "Fan control mode and PWM are both empty",
+		/*ErrFanPwmInvalid*/ "Invalid fan PWM " + argsStrs[0] + ", expected 1 to 100 percent",
+		/*ErrFanModeNotSupported*/ "Device does not expose a fan control mode",
+		/*ErrFanModeNotSupport*/ "The fan control mode " + argsStrs[0] + " is not supported, allowable values: " + argsStrs[1],
+		/*ErrFanPwmNotSupported*/ "Device does not expose a manual fan PWM",
+		/*ErrSetFanControlFailed*/ "Failed to set fan control " + argsStrs[0] + ", status code: " + argsStrs[1],
+		/*ErrBootOverrideNotSupported*/ "Device does not report a boot source override",
+		/*ErrClearBootOverrideFailed*/ "Failed to clear boot override, status code: " + argsStrs[0],
+		/*ErrGetAccountServiceFailed*/ "Failed to get account service, status code " + argsStrs[0],
+		/*ErrAccountPolicyNotSupported*/ "Device does not support setting account policy " + argsStrs[0],
+		/*ErrAccountPolicyInvalid*/ "Invalid account policy " + argsStrs[0] + " " + argsStrs[1] + ", expected " + argsStrs[2],
+		/*ErrSetAccountServiceFailed*/ "Failed to set account service, status code " + argsStrs[0],
+		/*ErrActionNotFound*/ "Action " + argsStrs[0] + " not found on device",
+		/*ErrBiosNotFound*/ "Device does not expose Bios settings",
+		/*ErrBiosDataInvalid*/ "Invalid Bios attributes: " + argsStrs[0],
+		/*ErrSetBiosFailed*/ "Failed to set Bios attributes, status code " + argsStrs[0],
+		/*ErrGetUpdateServiceFailed*/ "Failed to get update service, status code " + argsStrs[0],
+		/*ErrManagerFirmwareNotFound*/ "Device does not report a manager firmware version",
+		/*ErrSingleFirmwareBank*/ "Device manager has a single firmware bank, there is no other bank to switch to",
+		/*ErrSwitchFirmwareBankFailed*/ "Failed to switch firmware bank to " + argsStrs[0] + ", status code: " + argsStrs[1],
+		/*ErrHostNameNotSupported*/ "Device manager does not report an EthernetInterface HostName",
+		/*ErrHostNameInvalid*/ "Invalid host name " + argsStrs[0] + ", expected RFC 1123 labels of at most 63 letters, digits and hyphens",
+		/*ErrSetHostNameFailed*/ "Failed to set host name " + argsStrs[0] + ", status code: " + argsStrs[1],
+		/*ErrBootOrderNotSupported*/ "Device does not report a boot order",
+		/*ErrPolledDataNotFound*/ "The Redfish API " + argsStrs[0] + " has not been polled yet",
+		/*ErrActiveFirmwareBankUnknown*/ "Device manager does not report its active firmware image, the firmware bank cannot be switched",
 	}[e-1]
 }
 
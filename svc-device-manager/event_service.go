@@ -0,0 +1,69 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+/* getEventServiceStatus() reads ServiceEnabled and the status health of the device EventService
+ */
+func (s *Server) getEventServiceStatus(deviceIPAddress, authStr string) (enabled bool, health string, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return false, "", http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	eventService, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfEventService, userAuthData)
+	if statusCode != http.StatusOK || eventService == nil {
+		logrus.Errorf(ErrEventServiceNotSupported.String())
+		return false, "", http.StatusNotImplemented, errors.New(ErrEventServiceNotSupported.String())
+	}
+	enabled, ok := eventService["ServiceEnabled"].(bool)
+	if !ok {
+		//ServiceEnabled is optional, a service without it always runs
+		enabled = true
+	}
+	serviceStatus, _ := eventService["Status"].(map[string]interface{})
+	health, _ = serviceStatus["Health"].(string)
+	return enabled, health, http.StatusOK, nil
+}
+
+/* setEventServiceEnabled() patches ServiceEnabled of the device EventService
+ */
+func (s *Server) setEventServiceEnabled(deviceIPAddress, authStr string, enabled bool) (statusCode int, err error) {
+	if _, _, statusCode, err := s.getEventServiceStatus(deviceIPAddress, authStr); err != nil {
+		return statusCode, err
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	serviceInfo := map[string]interface{}{"ServiceEnabled": enabled}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, RfEventService, userAuthData, serviceInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetEventServiceFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrSetEventServiceFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
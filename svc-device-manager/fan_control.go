@@ -0,0 +1,200 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//fanSpeed holds the reading of one fan of a chassis Thermal resource
+type fanSpeed struct {
+	Name    string
+	Reading float64
+	Units   string
+}
+
+/* fanControl holds the fan control mode and the PWM duty the device exposes through OEM properties of its chassis
+ * Thermal resources or of its manager, with the resource and OEM property each is patched through
+ */
+type fanControl struct {
+	Mode            string
+	ModeAllowed     []string
+	ModeOdataID     string
+	ModeOemVendor   string
+	ModeOemName     string
+	PwmPercent      float64
+	PwmOdataID      string
+	PwmOemVendor    string
+	PwmOemName      string
+	ManualSupported bool
+	Fans            []fanSpeed
+}
+
+/* findFanOemProperties() takes the first OEM string property named like "Mode" as the fan control mode and the
+ * first numeric one named like "PWM", "Duty" or "Percent" as the PWM duty, a manager property must also be named
+ * like "Fan"
+ */
+func findFanOemProperties(odataID string, data map[string]interface{}, fanNamed bool, control *fanControl) {
+	oem, _ := data["Oem"].(map[string]interface{})
+	vendors := []string{}
+	for vendor := range oem {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+	for _, vendor := range vendors {
+		vendorData, _ := oem[vendor].(map[string]interface{})
+		names := []string{}
+		for name := range vendorData {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lowerName := strings.ToLower(name)
+			if fanNamed && !strings.Contains(lowerName, "fan") {
+				continue
+			}
+			if mode, ok := vendorData[name].(string); ok && control.ModeOemName == "" && strings.Contains(lowerName, "mode") {
+				control.Mode, control.ModeOdataID, control.ModeOemVendor, control.ModeOemName = mode, odataID, vendor, name
+				control.ModeAllowed = getAllowableValues(vendorData, name)
+			}
+			if percent, ok := vendorData[name].(float64); ok && control.PwmOemName == "" &&
+				(strings.Contains(lowerName, "pwm") || strings.Contains(lowerName, "duty") || strings.Contains(lowerName, "percent")) {
+				control.PwmPercent, control.PwmOdataID, control.PwmOemVendor, control.PwmOemName = percent, odataID, vendor, name
+			}
+		}
+	}
+}
+
+/* getFanControl() reads the fans of every chassis Thermal resource and the OEM fan control mode and PWM duty of
+ * the Thermal resources or of the first manager. Manual control is supported when the PWM duty can be set or one
+ * of the allowable modes is a manual one
+ */
+func (s *Server) getFanControl(deviceIPAddress, authStr string) (control fanControl, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return control, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, chassisOdataID := range getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData) {
+		thermalOdataID := chassisOdataID + "/Thermal"
+		thermalData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, thermalOdataID, userAuthData)
+		fans, _ := thermalData["Fans"].([]interface{})
+		for _, fan := range fans {
+			fanData, ok := fan.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			speed := fanSpeed{}
+			speed.Name, _ = fanData["Name"].(string)
+			if speed.Name == "" {
+				speed.Name, _ = fanData["FanName"].(string)
+			}
+			speed.Reading, _ = fanData["Reading"].(float64)
+			speed.Units, _ = fanData["ReadingUnits"].(string)
+			control.Fans = append(control.Fans, speed)
+		}
+		findFanOemProperties(thermalOdataID, thermalData, false, &control)
+	}
+	if managers, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id"); len(managers) != 0 {
+		managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, managers[0], userAuthData)
+		findFanOemProperties(managers[0], managerData, true, &control)
+	}
+	if len(control.Fans) == 0 && control.ModeOemName == "" && control.PwmOemName == "" {
+		logrus.Errorf(ErrFanControlNotSupported.String())
+		return control, http.StatusNotImplemented, errors.New(ErrFanControlNotSupported.String())
+	}
+	control.ManualSupported = control.PwmOemName != ""
+	for _, mode := range control.ModeAllowed {
+		if strings.Contains(strings.ToLower(mode), "manual") {
+			control.ManualSupported = true
+		}
+	}
+	return control, http.StatusOK, nil
+}
+
+/* setFanControl() patches the OEM fan control mode and, when pwmPercent is not 0, the OEM PWM duty of the device,
+ * the mode is patched first as devices take a manual duty only in a manual mode
+ */
+func (s *Server) setFanControl(deviceIPAddress, authStr, mode string, pwmPercent uint32) (statusCode int, err error) {
+	if mode == "" && pwmPercent == 0 {
+		logrus.Errorf(ErrFanControlEmpty.String())
+		return http.StatusBadRequest, errors.New(ErrFanControlEmpty.String())
+	}
+	if pwmPercent > 100 {
+		logrus.Errorf(ErrFanPwmInvalid.String(strconv.FormatUint(uint64(pwmPercent), 10)))
+		return http.StatusBadRequest, errors.New(ErrFanPwmInvalid.String(strconv.FormatUint(uint64(pwmPercent), 10)))
+	}
+	control, statusCode, err := s.getFanControl(deviceIPAddress, authStr)
+	if err != nil {
+		return statusCode, err
+	}
+	type oemPatch struct {
+		odataID string
+		vendor  string
+		name    string
+		value   interface{}
+	}
+	var patches []oemPatch
+	if mode != "" {
+		if control.ModeOemName == "" {
+			logrus.Errorf(ErrFanModeNotSupported.String())
+			return http.StatusNotImplemented, errors.New(ErrFanModeNotSupported.String())
+		}
+		if len(control.ModeAllowed) != 0 {
+			found := false
+			for _, option := range control.ModeAllowed {
+				if option == mode {
+					found = true
+					break
+				}
+			}
+			if !found {
+				logrus.Errorf(ErrFanModeNotSupport.String(mode, strings.Join(control.ModeAllowed, " ")))
+				return http.StatusBadRequest, errors.New(ErrFanModeNotSupport.String(mode, strings.Join(control.ModeAllowed, " ")))
+			}
+		}
+		patches = append(patches, oemPatch{control.ModeOdataID, control.ModeOemVendor, control.ModeOemName, mode})
+	}
+	if pwmPercent != 0 {
+		if control.PwmOemName == "" {
+			logrus.Errorf(ErrFanPwmNotSupported.String())
+			return http.StatusNotImplemented, errors.New(ErrFanPwmNotSupported.String())
+		}
+		patches = append(patches, oemPatch{control.PwmOdataID, control.PwmOemVendor, control.PwmOemName, pwmPercent})
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	for _, patch := range patches {
+		data := map[string]interface{}{"Oem": map[string]interface{}{patch.vendor: map[string]interface{}{patch.name: patch.value}}}
+		_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, patch.odataID, userAuthData, data)
+		if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+			logrus.Errorf(ErrSetFanControlFailed.String(patch.name, strconv.Itoa(statusCode)))
+			return statusCode, errors.New(ErrSetFanControlFailed.String(patch.name, strconv.Itoa(statusCode)))
+		}
+	}
+	return statusCode, nil
+}
@@ -23,7 +23,9 @@ package main
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -62,17 +64,38 @@ type userAuth struct {
 }
 
 type device struct {
-	Freq          uint32              `json:"frequency"`
-	Datacollector scheduler           `json:"-"`
-	Freqchan      chan uint32         `json:"-"`
-	UserLoginInfo map[string]userAuth `json:"userlogin"`
-	QueryState    bool                `json:"-"`
-	QueryUser     userAuth            `json:"-"`
-	RfAPIList     []string            `json:"redfishAPIList"`
-	ContentType   string              `json:"ContentType"`
-	HTTPType      string              `json:"HTTPType"`
-	UserAuthLock  sync.Mutex          `json:"-"`
-	PassAuth      bool                `json:"passAuth"`
+	Freq                   uint32                `json:"frequency"`
+	Datacollector          scheduler             `json:"-"`
+	Freqchan               chan uint32           `json:"-"`
+	FreqLock               sync.Mutex            `json:"-"`
+	UserLoginInfo          map[string]userAuth   `json:"userlogin"`
+	QueryState             bool                  `json:"-"`
+	QueryUser              userAuth              `json:"-"`
+	RfAPIList              []string              `json:"redfishAPIList"`
+	ContentType            string                `json:"ContentType"`
+	HTTPType               string                `json:"HTTPType"`
+	UserAuthLock           sync.Mutex            `json:"-"`
+	PassAuth               bool                  `json:"passAuth"`
+	PowerHistory           []powerSample         `json:"-"`
+	PowerLock              sync.Mutex            `json:"-"`
+	LogCursors             map[string]*logCursor `json:"-"`
+	LogCursorLock          sync.Mutex            `json:"-"`
+	PollFailures           int                   `json:"-"`
+	PollSkips              int                   `json:"-"`
+	Degraded               bool                  `json:"-"`
+	CertCheckedAt          time.Time             `json:"-"`
+	PolledData             map[string]string     `json:"-"`
+	PolledDataLock         sync.Mutex            `json:"-"`
+	LastPollAt             time.Time             `json:"-"`
+	LastPollOKAt           time.Time             `json:"-"`
+	LastPollError          string                `json:"-"`
+	PollStateLock          sync.Mutex            `json:"-"`
+	SyslogTargets          []string              `json:"-"`
+	SubscriptionsCheckedAt time.Time             `json:"-"`
+	SyslogLock             sync.Mutex            `json:"-"`
+	MaintenanceStart       time.Time             `json:"-"`
+	MaintenanceEnd         time.Time             `json:"-"`
+	MaintenanceLock        sync.Mutex            `json:"-"`
 }
 
 //Server ...
@@ -80,6 +103,10 @@ type Server struct {
 	devicemap    map[string]*device
 	gRPCserver   *grpc.Server
 	dataproducer sarama.AsyncProducer
+	groups       map[string][]string
+	groupLock    sync.Mutex
+	//devicemapLock guards adding and removing devices against the goroutine of the metrics endpoint
+	devicemapLock sync.RWMutex
 }
 
 //DefaultDetectDevice ...
@@ -111,6 +138,30 @@ func (s *Server) SetHTTPType(c context.Context, device *manager.Device) (*empty.
 	return &empty.Empty{}, nil
 }
 
+//SetPollTimeout ...
+func (s *Server) SetPollTimeout(c context.Context, device *manager.Device) (*empty.Empty, error) {
+	logrus.Info("Received SetPollTimeout")
+	if device == nil || len(device.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := device.IpAddress
+	authStr := device.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	if device.PollTimeout > MaxPollTimeout {
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(ErrPollTimeoutInvalid.String(strconv.Itoa(MaxPollTimeout)))
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrPollTimeoutInvalid.String(strconv.Itoa(MaxPollTimeout)))
+	}
+	setPollTimeout(ipAddress, device.PollTimeout)
+	return &empty.Empty{}, nil
+}
+
 //GetHTTPType ...
 func (s *Server) GetHTTPType(c context.Context, device *manager.Device) (*manager.Device, error) {
 	logrus.Info("Received GetHTTPType")
@@ -271,10 +322,22 @@ func (s *Server) DeleteDeviceList(c context.Context, device *manager.Device) (*e
 	}
 	s.devicemap[ipAddress].Datacollector.quit <- true
 	<-s.devicemap[ipAddress].Datacollector.getdataend
+	s.devicemapLock.Lock()
 	delete(s.devicemap, ipAddress)
+	s.devicemapLock.Unlock()
+	s.removeDeviceFromGroups(ipAddress)
 	return &empty.Empty{}, nil
 }
 
+func (s *Server) countDevices() (count int) {
+	for _, dev := range s.devicemap {
+		if dev != nil {
+			count++
+		}
+	}
+	return count
+}
+
 //SendDeviceList ...
 func (s *Server) SendDeviceList(c context.Context, list *manager.DeviceList) (*empty.Empty, error) {
 	logrus.Info("Received SendDeviceList")
@@ -290,15 +353,25 @@ func (s *Server) SendDeviceList(c context.Context, list *manager.DeviceList) (*e
 				"IP address:port": ipAddress}).Error(msg)
 			return &empty.Empty{}, status.Errorf(http.StatusBadRequest, msg)
 		}
+		if dev.Frequency > 0 && dev.Frequency < RfDataCollectThreshold {
+			logrus.WithFields(logrus.Fields{
+				"IP address:port": ipAddress}).Error(ErrFreqValueInvalid.String())
+			return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrFreqValueInvalid.String())
+		}
+		//attaching an attached device again, e.g. a retry of a timed out attach, updates it instead of failing
+		if s.devicemap[ipAddress] != nil {
+			s.updateAttachedDevice(ipAddress, dev.Frequency, dev.PassAuth, dev.SkipTLSVerify)
+			continue
+		}
 		if s.vlidateDeviceRegistered(ipAddress) == true {
 			logrus.WithFields(logrus.Fields{
 				"IP address:port": ipAddress}).Error(ErrHasRegistered.String(ipAddress))
 			return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrHasRegistered.String(ipAddress))
 		}
-		if dev.Frequency > 0 && dev.Frequency < RfDataCollectThreshold {
+		if attached := s.countDevices(); GlobalConfig.MaxDevices > 0 && attached >= GlobalConfig.MaxDevices {
 			logrus.WithFields(logrus.Fields{
-				"IP address:port": ipAddress}).Error(ErrFreqValueInvalid.String())
-			return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrFreqValueInvalid.String())
+				"IP address:port": ipAddress}).Error(ErrMaxDevicesReached.String(strconv.Itoa(attached), strconv.Itoa(GlobalConfig.MaxDevices)))
+			return &empty.Empty{}, status.Errorf(codes.ResourceExhausted, ErrMaxDevicesReached.String(strconv.Itoa(attached), strconv.Itoa(GlobalConfig.MaxDevices)))
 		}
 		d := device{
 			Freq: dev.Frequency,
@@ -306,10 +379,12 @@ func (s *Server) SendDeviceList(c context.Context, list *manager.DeviceList) (*e
 				quit:       make(chan bool),
 				getdataend: make(chan bool),
 			},
-			Freqchan:      make(chan uint32),
+			Freqchan:      make(chan uint32, 1),
 			UserLoginInfo: make(map[string]userAuth),
 		}
+		s.devicemapLock.Lock()
 		s.devicemap[ipAddress] = &d
+		s.devicemapLock.Unlock()
 		logrus.Infof("Configuring  %s", ipAddress)
 		/* if initial interval is 0, create a dummy ticker, which is stopped right away, so getdata is not nil */
 		freq := dev.Frequency
@@ -325,6 +400,12 @@ func (s *Server) SendDeviceList(c context.Context, list *manager.DeviceList) (*e
 		go s.collectData(ipAddress)
 		s.devicemap[ipAddress].RfAPIList = redfishResources
 		RfProtocol[ipAddress] = RfDefaultHttpsProtocol
+		setTLSSkipVerify(ipAddress, dev.SkipTLSVerify)
+		setPollTimeout(ipAddress, 0)
+		if dev.SkipTLSVerify {
+			logrus.WithFields(logrus.Fields{
+				"IP address:port": ipAddress}).Warn("device certificate verification is disabled")
+		}
 		s.devicemap[ipAddress].HTTPType = RfDefaultHttpsProtocol
 		ContentType[ipAddress] = DefaultContentType
 		s.devicemap[ipAddress].ContentType = DefaultContentType
@@ -391,15 +472,107 @@ func (s *Server) GetCurrentDevices(c context.Context, e *manager.Empty) (*manage
 		return nil, status.Errorf(http.StatusBadRequest, ErrNoDevice.String())
 	}
 	deviceList := new(manager.DeviceListByIp)
+	deviceList.State = make(map[string]string)
 	for k, v := range s.devicemap {
 		if v != nil {
 			logrus.Infof("IpAdd[%s]", k)
 			deviceList.IpAddress = append(deviceList.IpAddress, k)
+			deviceList.State[k] = s.getDevicePollState(k)
 		}
 	}
 	return deviceList, nil
 }
 
+//GetVersion ...
+func (s *Server) GetVersion(c context.Context, e *manager.Empty) (*manager.Version, error) {
+	logrus.Info("Received GetVersion")
+	return &manager.Version{Version: version, GitCommit: gitCommit, BuildDate: buildDate, GoVersion: goVersion(), RedfishLibVersion: redfishLibVersion()}, nil
+}
+
+//GetFleetTelemetry ...
+func (s *Server) GetFleetTelemetry(c context.Context, e *manager.Empty) (*manager.FleetTelemetry, error) {
+	logrus.Info("Received GetFleetTelemetry")
+	if len(s.devicemap) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrNoDevice.String())
+	}
+	fleetTelemetry := new(manager.FleetTelemetry)
+	for _, telemetry := range s.getFleetTelemetry() {
+		deviceTelemetry := &manager.DeviceTelemetry{
+			IpAddress:             telemetry.IPAddress,
+			State:                 telemetry.State,
+			ProcessorCount:        telemetry.ProcessorCount,
+			LogicalProcessorCount: telemetry.LogicalProcessorCount,
+			ProcessorHealth:       telemetry.ProcessorHealth,
+			MemoryGiB:             telemetry.MemoryGiB,
+			MemoryHealth:          telemetry.MemoryHealth,
+			DriveCount:            telemetry.DriveCount,
+			StorageBytes:          telemetry.StorageBytes,
+		}
+		if !telemetry.PolledAt.IsZero() {
+			deviceTelemetry.PolledAt = telemetry.PolledAt.Unix()
+		}
+		fleetTelemetry.Devices = append(fleetTelemetry.Devices, deviceTelemetry)
+	}
+	return fleetTelemetry, nil
+}
+
+//CreateGroup ...
+func (s *Server) CreateGroup(c context.Context, group *manager.DeviceGroup) (*empty.Empty, error) {
+	logrus.Info("Received CreateGroup")
+	if group == nil {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrGroupNameEmpty.String())
+	}
+	statusCode, err := s.createGroup(group.Name)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"Group": group.Name,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//AddDeviceToGroup ...
+func (s *Server) AddDeviceToGroup(c context.Context, group *manager.DeviceGroup) (*empty.Empty, error) {
+	logrus.Info("Received AddDeviceToGroup")
+	if group == nil || len(group.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	funcs := []string{"checkIPAddress", "checkRegistered"}
+	functionArgs := [][]string{{"false"}, {""}}
+	for _, ipAddress := range group.IpAddress {
+		for id, f := range funcs {
+			if _, err := s.getFunctionsResult(f, ipAddress, "", functionArgs[id]...); err != nil {
+				return &empty.Empty{}, err
+			}
+		}
+	}
+	statusCode, err := s.addDeviceToGroup(group.Name, group.IpAddress)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"Group": group.Name,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//ListGroups ...
+func (s *Server) ListGroups(c context.Context, e *manager.Empty) (*manager.DeviceGroupList, error) {
+	logrus.Info("Received ListGroups")
+	groups, statusCode, err := s.listGroups()
+	if err != nil {
+		return nil, status.Errorf(codes.Code(statusCode), err.Error())
+	}
+	groupList := new(manager.DeviceGroupList)
+	for name, members := range groups {
+		groupList.Group = append(groupList.Group, &manager.DeviceGroup{Name: name, IpAddress: members})
+	}
+	return groupList, nil
+}
+
 //CreateDeviceAccount ...
 func (s *Server) CreateDeviceAccount(c context.Context, account *manager.DeviceAccount) (*empty.Empty, error) {
 	logrus.Info("Received CreateDeviceAccount")
@@ -652,6 +825,261 @@ func (s *Server) SetSessionService(c context.Context, account *manager.DeviceAcc
 	return &empty.Empty{}, nil
 }
 
+//GetSessionService ...
+func (s *Server) GetSessionService(c context.Context, account *manager.DeviceAccount) (*manager.DeviceAccount, error) {
+	logrus.Info("Received GetSessionService")
+	if account == nil || len(account.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := account.IpAddress
+	var authStr string
+	authStr = account.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	sessionEnabled, sessionTimeout, statusCode, err := s.getSessionService(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	sessionService := new(manager.DeviceAccount)
+	sessionService.IpAddress = ipAddress
+	sessionService.SessionEnabled = sessionEnabled
+	sessionService.SessionTimeout = sessionTimeout
+	return sessionService, nil
+}
+
+//GetAccountServicePolicy ...
+func (s *Server) GetAccountServicePolicy(c context.Context, policyData *manager.AccountServicePolicy) (*manager.AccountServicePolicy, error) {
+	logrus.Info("Received GetAccountServicePolicy")
+	if policyData == nil || len(policyData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := policyData.IpAddress
+	authStr := policyData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	policy, statusCode, err := s.getAccountServicePolicy(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	policyData.MinPasswordLength = policy.MinPasswordLength
+	policyData.MaxPasswordLength = policy.MaxPasswordLength
+	policyData.AccountLockoutThreshold = policy.AccountLockoutThreshold
+	policyData.AccountLockoutDuration = policy.AccountLockoutDuration
+	policyData.AccountLockoutCounterResetAfter = policy.AccountLockoutCounterResetAfter
+	policyData.NotReported = policy.NotReported
+	return policyData, nil
+}
+
+//SetAccountServicePolicy ...
+func (s *Server) SetAccountServicePolicy(c context.Context, policyData *manager.AccountServicePolicy) (*manager.AccountServicePolicy, error) {
+	logrus.Info("Received SetAccountServicePolicy")
+	if policyData == nil || len(policyData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := policyData.IpAddress
+	authStr := policyData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	values := map[string]uint32{
+		"AccountLockoutThreshold": policyData.AccountLockoutThreshold,
+		"AccountLockoutDuration":  policyData.AccountLockoutDuration,
+		"MinPasswordLength":       policyData.MinPasswordLength,
+	}
+	settings := map[string]uint32{}
+	for _, property := range policyData.SetProperties {
+		settings[property] = values[property]
+	}
+	policy, statusCode, err := s.setAccountServicePolicy(ipAddress, authStr, settings)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Properties":      policyData.SetProperties,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	policyData.MinPasswordLength = policy.MinPasswordLength
+	policyData.MaxPasswordLength = policy.MaxPasswordLength
+	policyData.AccountLockoutThreshold = policy.AccountLockoutThreshold
+	policyData.AccountLockoutDuration = policy.AccountLockoutDuration
+	policyData.AccountLockoutCounterResetAfter = policy.AccountLockoutCounterResetAfter
+	policyData.NotReported = policy.NotReported
+	return policyData, nil
+}
+
+//GetFirmwareInventory ...
+func (s *Server) GetFirmwareInventory(c context.Context, inventory *manager.FirmwareInventory) (*manager.FirmwareInventory, error) {
+	logrus.Info("Received GetFirmwareInventory")
+	if inventory == nil || len(inventory.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := inventory.IpAddress
+	var authStr string
+	authStr = inventory.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	components, statusCode, err := s.getFirmwareInventory(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	firmwareInventory := new(manager.FirmwareInventory)
+	firmwareInventory.IpAddress = ipAddress
+	for _, component := range components {
+		firmwareInventory.Components = append(firmwareInventory.Components, &manager.FirmwareComponent{
+			Id:      component.ID,
+			Name:    component.Name,
+			Version: component.Version,
+			OdataId: component.OdataID,
+		})
+	}
+	return firmwareInventory, nil
+}
+
+//GetManagerFirmwareBanks ...
+func (s *Server) GetManagerFirmwareBanks(c context.Context, firmwareBanks *manager.ManagerFirmwareBanks) (*manager.ManagerFirmwareBanks, error) {
+	logrus.Info("Received GetManagerFirmwareBanks")
+	if firmwareBanks == nil || len(firmwareBanks.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := firmwareBanks.IpAddress
+	authStr := firmwareBanks.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	banks, statusCode, err := s.getManagerFirmwareBanks(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	firmwareBanks.Manager = banks.Manager
+	firmwareBanks.ActiveBank = banks.ActiveBank
+	firmwareBanks.ActiveVersion = banks.ActiveVersion
+	firmwareBanks.BackupBank = banks.BackupBank
+	firmwareBanks.BackupVersion = banks.BackupVersion
+	return firmwareBanks, nil
+}
+
+//SwitchFirmwareBank ...
+func (s *Server) SwitchFirmwareBank(c context.Context, bankSwitch *manager.FirmwareBankSwitch) (*manager.FirmwareBankSwitch, error) {
+	logrus.Info("Received SwitchFirmwareBank")
+	if bankSwitch == nil || len(bankSwitch.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := bankSwitch.IpAddress
+	authStr := bankSwitch.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	banks, statusCode, err := s.switchFirmwareBank(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	bankSwitch.Manager = banks.Manager
+	bankSwitch.PreviousBank = banks.ActiveBank
+	bankSwitch.PreviousVersion = banks.ActiveVersion
+	bankSwitch.TargetBank = banks.BackupBank
+	bankSwitch.TargetVersion = banks.BackupVersion
+	bankSwitch.ResetRequired = true
+	return bankSwitch, nil
+}
+
+//GetHostName ...
+func (s *Server) GetHostName(c context.Context, hostNameData *manager.HostName) (*manager.HostName, error) {
+	logrus.Info("Received GetHostName")
+	if hostNameData == nil || len(hostNameData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := hostNameData.IpAddress
+	authStr := hostNameData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	info, statusCode, err := s.getHostName(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	hostNameData.HostName = info.HostName
+	hostNameData.Fqdn = info.FQDN
+	hostNameData.Interface = info.Interface
+	return hostNameData, nil
+}
+
+//SetHostName ...
+func (s *Server) SetHostName(c context.Context, hostNameData *manager.HostName) (*manager.HostName, error) {
+	logrus.Info("Received SetHostName")
+	if hostNameData == nil || len(hostNameData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := hostNameData.IpAddress
+	authStr := hostNameData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	info, statusCode, err := s.setHostName(ipAddress, authStr, hostNameData.HostName)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	hostNameData.HostName = info.HostName
+	hostNameData.Fqdn = info.FQDN
+	hostNameData.Interface = info.Interface
+	return hostNameData, nil
+}
+
 //GetDeviceData ...
 func (s *Server) GetDeviceData(c context.Context, device *manager.Device) (*manager.DeviceData, error) {
 	logrus.Info("Received GetDeviceData")
@@ -679,7 +1107,7 @@ func (s *Server) GetDeviceData(c context.Context, device *manager.Device) (*mana
 			return nil, err
 		}
 	}
-	statusCode, deviceData, err := s.getDeviceDataByFileData(ipAddress, redfishAPI)
+	statusCode, deviceData, err := s.getDeviceDataByPolledData(ipAddress, redfishAPI)
 	if err != nil || statusCode != http.StatusOK {
 		errStatus, _ := status.FromError(err)
 		logrus.WithFields(logrus.Fields{
@@ -688,11 +1116,54 @@ func (s *Server) GetDeviceData(c context.Context, device *manager.Device) (*mana
 		}).Error(errStatus.Message())
 		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
 	}
+	deviceData = applyOemRules(deviceData)
+	if device.JsonPath != "" {
+		deviceData, statusCode, err = extractJSONPath(deviceData, device.JsonPath)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"IP address:port": ipAddress,
+				"Redfish API":     redfishAPI,
+			}).Error(err.Error())
+			return nil, status.Errorf(codes.Code(statusCode), err.Error())
+		}
+	}
 	deviceRedfishData := new(manager.DeviceData)
 	deviceRedfishData.DeviceData = deviceData
 	return deviceRedfishData, nil
 }
 
+//DiffDevices ...
+func (s *Server) DiffDevices(c context.Context, deviceDiff *manager.DeviceDiff) (*manager.DeviceDiff, error) {
+	logrus.Info("Received DiffDevices")
+	if deviceDiff == nil || len(deviceDiff.IpAddress1) == 0 || len(deviceDiff.IpAddress2) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	if len(deviceDiff.RedfishAPI) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrRfAPIEmpty.String())
+	}
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, target := range [][]string{{deviceDiff.IpAddress1, deviceDiff.UserOrToken1}, {deviceDiff.IpAddress2, deviceDiff.UserOrToken2}} {
+		for _, f := range funcs {
+			if _, err := s.getFunctionsResult(f, target[0], target[1], ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+	diffs, statusCode, err := s.diffDevices(deviceDiff.IpAddress1, deviceDiff.UserOrToken1, deviceDiff.IpAddress2, deviceDiff.UserOrToken2, deviceDiff.RedfishAPI)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": deviceDiff.IpAddress1 + " " + deviceDiff.IpAddress2,
+			"Redfish API":     deviceDiff.RedfishAPI,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, diff := range diffs {
+		deviceDiff.Difference = append(deviceDiff.Difference, &manager.JsonDifference{Path: diff.Path, Kind: diff.Kind, Value1: diff.Value1, Value2: diff.Value2})
+	}
+	return deviceDiff, nil
+}
+
 func findRedfishAPIOnTheList(list []string, RedfishAPI string) bool {
 	found := false
 	for _, api := range list {
@@ -861,26 +1332,176 @@ func (s *Server) GetDeviceLogData(c context.Context, logDevice *manager.LogServi
 	return deviceLogData, nil
 }
 
-//SendDeviceSoftwareDownloadURI ...
-func (s *Server) SendDeviceSoftwareDownloadURI(c context.Context, softwareUpdate *manager.SoftwareUpdate) (*empty.Empty, error) {
-	logrus.Info("Received SendDeviceSoftwareDownloadURI")
-	if softwareUpdate == nil || len(softwareUpdate.IpAddress) == 0 {
-		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrSWDataEmpty.String())
+//StreamDeviceLogData ...
+func (s *Server) StreamDeviceLogData(logDevice *manager.LogService, stream manager.DeviceManagement_StreamDeviceLogDataServer) error {
+	logrus.Info("Received StreamDeviceLogData")
+	if logDevice == nil || len(logDevice.IpAddress) == 0 {
+		return status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
 	}
-	ipAddress := softwareUpdate.IpAddress
-	softwareDownloadType := softwareUpdate.SoftwareDownloadType
-	softwareDownloadURI := softwareUpdate.SoftwareDownloadURI
+	ipAddress := logDevice.IpAddress
+	id := logDevice.Id
 	var authStr string
-	authStr = softwareUpdate.UserOrToken
-	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus", "userPrivilegeOnlyUsers"}
-	functionArgs := [][]string{{""}, {""}, {""}, {""}, {"", ErrUserPrivilege.String()}}
-	for id, f := range funcs {
-		if _, err := s.getFunctionsResult(f, ipAddress, authStr, functionArgs[id]...); err != nil {
-			return &empty.Empty{}, err
-		}
-	}
-	statusCode, err := s.sendDeviceSoftwareDownloadURI(ipAddress, authStr, softwareDownloadType, softwareDownloadURI)
-	if err != nil && statusCode != http.StatusOK {
+	authStr = logDevice.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return err
+		}
+	}
+	statusCode, err := s.streamDeviceLogData(ipAddress, authStr, id, func(entries []string) error {
+		return stream.Send(&manager.LogService{LogData: entries})
+	})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Log Member Id":   id,
+		}).Error(errStatus.Message())
+		return status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return nil
+}
+
+//ListLogServices ...
+func (s *Server) ListLogServices(c context.Context, logDevice *manager.LogService) (*manager.LogService, error) {
+	logrus.Info("Received ListLogServices")
+	if logDevice == nil || len(logDevice.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := logDevice.IpAddress
+	var authStr string
+	authStr = logDevice.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	services, statusCode, err := s.listDeviceLogServices(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	deviceLogServices := new(manager.LogService)
+	for _, service := range services {
+		deviceLogServices.LogServices = append(deviceLogServices.LogServices, &manager.LogServiceInfo{
+			Id:             service.ID,
+			OdataId:        service.OdataID,
+			ServiceEnabled: service.ServiceEnabled,
+		})
+	}
+	return deviceLogServices, nil
+}
+
+//GetNewDeviceLogData ...
+func (s *Server) GetNewDeviceLogData(c context.Context, logDevice *manager.LogService) (*manager.LogService, error) {
+	logrus.Info("Received GetNewDeviceLogData")
+	if logDevice == nil || len(logDevice.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := logDevice.IpAddress
+	id := logDevice.Id
+	var authStr string
+	authStr = logDevice.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	logData, statusCode, err := s.getNewDeviceLogData(ipAddress, authStr, id, logDevice.StreamId)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Log Member Id":   id,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	deviceLogData := new(manager.LogService)
+	deviceLogData.LogData = logData
+	return deviceLogData, nil
+}
+
+//SetSyslogTarget ...
+func (s *Server) SetSyslogTarget(c context.Context, syslogTarget *manager.SyslogTarget) (*empty.Empty, error) {
+	logrus.Info("Received SetSyslogTarget")
+	if syslogTarget == nil || len(syslogTarget.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := syslogTarget.IpAddress
+	var authStr string
+	authStr = syslogTarget.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus", "userPrivilegeOnlyUsers"}
+	functionArgs := [][]string{{""}, {""}, {""}, {""}, {"", ErrUserPrivilege.String()}}
+	for id, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, functionArgs[id]...); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setSyslogTarget(ipAddress, authStr, syslogTarget.Host, syslogTarget.Port)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Syslog host":     syslogTarget.Host,
+			"Syslog port":     syslogTarget.Port,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//ClearSyslogTargets ...
+func (s *Server) ClearSyslogTargets(c context.Context, syslogTarget *manager.SyslogTarget) (*manager.SyslogTarget, error) {
+	logrus.Info("Received ClearSyslogTargets")
+	if syslogTarget == nil || len(syslogTarget.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := syslogTarget.IpAddress
+	authStr := syslogTarget.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus", "userPrivilegeOnlyUsers"}
+	functionArgs := [][]string{{""}, {""}, {""}, {""}, {"", ErrUserPrivilege.String()}}
+	for id, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, functionArgs[id]...); err != nil {
+			return nil, err
+		}
+	}
+	deleted, statusCode, err := s.clearSyslogTargets(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	syslogTarget.Unsubscribed = deleted
+	return syslogTarget, nil
+}
+
+//SendDeviceSoftwareDownloadURI ...
+func (s *Server) SendDeviceSoftwareDownloadURI(c context.Context, softwareUpdate *manager.SoftwareUpdate) (*empty.Empty, error) {
+	logrus.Info("Received SendDeviceSoftwareDownloadURI")
+	if softwareUpdate == nil || len(softwareUpdate.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrSWDataEmpty.String())
+	}
+	ipAddress := softwareUpdate.IpAddress
+	softwareDownloadType := softwareUpdate.SoftwareDownloadType
+	softwareDownloadURI := softwareUpdate.SoftwareDownloadURI
+	var authStr string
+	authStr = softwareUpdate.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus", "userPrivilegeOnlyUsers"}
+	functionArgs := [][]string{{""}, {""}, {""}, {""}, {"", ErrUserPrivilege.String()}}
+	for id, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, functionArgs[id]...); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.sendDeviceSoftwareDownloadURI(ipAddress, authStr, softwareDownloadType, softwareDownloadURI, softwareUpdate.ApplyTime)
+	if err != nil && statusCode != http.StatusOK {
 		errStatus, _ := status.FromError(err)
 		logrus.WithFields(logrus.Fields{
 			"IP address:port": ipAddress,
@@ -890,6 +1511,117 @@ func (s *Server) SendDeviceSoftwareDownloadURI(c context.Context, softwareUpdate
 	return &empty.Empty{}, nil
 }
 
+//PushDeviceSoftware ...
+func (s *Server) PushDeviceSoftware(stream manager.DeviceManagement_PushDeviceSoftwareServer) error {
+	logrus.Info("Received PushDeviceSoftware")
+	softwareImage, err := stream.Recv()
+	if err != nil || len(softwareImage.IpAddress) == 0 {
+		return status.Errorf(http.StatusBadRequest, ErrSWDataEmpty.String())
+	}
+	ipAddress := softwareImage.IpAddress
+	var authStr string
+	authStr = softwareImage.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus", "userPrivilegeOnlyUsers"}
+	functionArgs := [][]string{{""}, {""}, {""}, {""}, {"", ErrUserPrivilege.String()}}
+	for id, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, functionArgs[id]...); err != nil {
+			return err
+		}
+	}
+	imageReader, imageWriter := io.Pipe()
+	defer imageReader.Close()
+	go func() {
+		if _, err := imageWriter.Write(softwareImage.Data); err != nil {
+			return
+		}
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				imageWriter.Close()
+				return
+			}
+			if err != nil {
+				imageWriter.CloseWithError(err)
+				return
+			}
+			if _, err := imageWriter.Write(chunk.Data); err != nil {
+				return
+			}
+		}
+	}()
+	taskURI, statusCode, err := s.pushDeviceSoftware(ipAddress, authStr, softwareImage.FileName, softwareImage.Targets, softwareImage.ApplyTime, imageReader)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"File name":       softwareImage.FileName,
+		}).Error(errStatus.Message())
+		return status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return stream.SendAndClose(&manager.Task{TaskURI: taskURI})
+}
+
+//CancelSoftwareUpdate ...
+func (s *Server) CancelSoftwareUpdate(c context.Context, softwareUpdate *manager.SoftwareUpdate) (*manager.Task, error) {
+	logrus.Info("Received CancelSoftwareUpdate")
+	if softwareUpdate == nil || len(softwareUpdate.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrSWDataEmpty.String())
+	}
+	ipAddress := softwareUpdate.IpAddress
+	var authStr string
+	authStr = softwareUpdate.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus", "userPrivilegeOnlyUsers"}
+	functionArgs := [][]string{{""}, {""}, {""}, {""}, {"", ErrUserPrivilege.String()}}
+	for id, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, functionArgs[id]...); err != nil {
+			return nil, err
+		}
+	}
+	taskURI, statusCode, err := s.cancelSoftwareUpdate(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Task":            taskURI,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &manager.Task{TaskURI: taskURI}, nil
+}
+
+//GetUpdateServiceStatus ...
+func (s *Server) GetUpdateServiceStatus(c context.Context, updateData *manager.UpdateServiceStatus) (*manager.UpdateServiceStatus, error) {
+	logrus.Info("Received GetUpdateServiceStatus")
+	if updateData == nil || len(updateData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := updateData.IpAddress
+	authStr := updateData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "userStatus", "loginStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	updateStatus, statusCode, err := s.getUpdateServiceStatus(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	updateData.ServiceEnabled = updateStatus.ServiceEnabled
+	updateData.Health = updateStatus.Health
+	updateData.TransferProtocols = updateStatus.TransferProtocols
+	updateData.MultipartPush = updateStatus.MultipartPush
+	updateData.ApplyTimes = updateStatus.ApplyTimes
+	updateData.ActiveTask = updateStatus.ActiveTask
+	updateData.ActiveTaskState = updateStatus.ActiveTaskState
+	updateData.ActiveTaskPercent = updateStatus.ActiveTaskPercent
+	return updateData, nil
+}
+
 //AddPollingRfAPI ...
 func (s *Server) AddPollingRfAPI(c context.Context, device *manager.Device) (*empty.Empty, error) {
 	logrus.Info("Received AddPollingRfAPI")
@@ -1005,6 +1737,60 @@ func (s *Server) GetRfAPIList(c context.Context, device *manager.Device) (*manag
 	return rfAPIList, nil
 }
 
+//SetPollingRfAPIList ...
+func (s *Server) SetPollingRfAPIList(c context.Context, pollingList *manager.PollingRfAPIList) (*manager.RfAPIList, error) {
+	logrus.Info("Received SetPollingRfAPIList")
+	if pollingList == nil || len(pollingList.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := pollingList.IpAddress
+	authStr := pollingList.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeOnlyUsers"}
+	functionArgs := [][]string{{""}, {""}, {""}, {""}, {"", ErrUserPrivilege.String()}}
+	for id, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, functionArgs[id]...); err != nil {
+			return nil, err
+		}
+	}
+	list, statusCode, err := s.setPollingRfAPIList(ipAddress, authStr, pollingList.RfAPIList)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &manager.RfAPIList{RfAPIList: list}, nil
+}
+
+//PollDeviceNow ...
+func (s *Server) PollDeviceNow(c context.Context, pollNow *manager.PollNow) (*manager.PollNow, error) {
+	logrus.Info("Received PollDeviceNow")
+	if pollNow == nil || len(pollNow.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := pollNow.IpAddress
+	authStr := pollNow.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	result, statusCode, err := s.pollNow(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	pollNow.Polled = uint32(result.Polled)
+	pollNow.Total = uint32(result.Total)
+	pollNow.LastError = result.LastError
+	return pollNow, nil
+}
+
 //GetDeviceSupportedResetType ...
 func (s *Server) GetDeviceSupportedResetType(c context.Context, systemBootData *manager.SystemBoot) (*manager.SystemBoot, error) {
 	logrus.Info("Received GetDeviceSupportedResetType")
@@ -1061,38 +1847,1038 @@ func (s *Server) ResetDeviceSystem(c context.Context, systemBootData *manager.Sy
 	return &empty.Empty{}, nil
 }
 
-//GetDeviceTemperatures ...
-func (s *Server) GetDeviceTemperatures(c context.Context, deviceTemperature *manager.DeviceTemperature) (*manager.DeviceTemperature, error) {
-	logrus.Info("Received GetDeviceTemperatures")
-	if deviceTemperature == nil || len(deviceTemperature.IpAddress) == 0 {
-		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+//ResetBmcToDefaults ...
+func (s *Server) ResetBmcToDefaults(c context.Context, systemBootData *manager.SystemBoot) (*empty.Empty, error) {
+	logrus.Info("Received ResetBmcToDefaults")
+	if systemBootData == nil || len(systemBootData.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrResetTypeEmpty.String())
 	}
-	ipAddress := deviceTemperature.IpAddress
+	ipAddress := systemBootData.IpAddress
+	resetType := systemBootData.ResetType
 	var authStr string
-	authStr = deviceTemperature.UserOrToken
+	authStr = systemBootData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	if !systemBootData.Confirmed {
+		logrus.Errorf(ErrResetToDefaultsNotConfirmed.String())
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrResetToDefaultsNotConfirmed.String())
+	}
+	statusCode, err := s.resetBmcToDefaults(ipAddress, authStr, resetType)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Reset type":      resetType,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetManagerDateTime ...
+func (s *Server) GetManagerDateTime(c context.Context, managerDateTime *manager.ManagerDateTime) (*manager.ManagerDateTime, error) {
+	logrus.Info("Received GetManagerDateTime")
+	if managerDateTime == nil || len(managerDateTime.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := managerDateTime.IpAddress
+	authStr := managerDateTime.UserOrToken
 	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
 	for _, f := range funcs {
 		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
 			return nil, err
 		}
 	}
-	deviceTemp, statusCode, err := s.getDeviceTemperature(ipAddress, authStr)
-	if err != nil && statusCode != http.StatusOK {
+	dateTime, localOffset, statusCode, err := s.getManagerDateTime(ipAddress, authStr)
+	if err != nil {
 		errStatus, _ := status.FromError(err)
 		logrus.WithFields(logrus.Fields{
 			"IP address:port": ipAddress,
 		}).Error(errStatus.Message())
 		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
 	}
-	deviceTempData := new(manager.DeviceTemperature)
-	deviceTempData.TempData = deviceTemp
-	return deviceTempData, nil
+	managerDateTime.DateTime = dateTime
+	managerDateTime.DateTimeLocalOffset = localOffset
+	return managerDateTime, nil
 }
 
-//SetDeviceTemperatureForEvent ...
-func (s *Server) SetDeviceTemperatureForEvent(c context.Context, deviceTemperature *manager.DeviceTemperature) (*empty.Empty, error) {
-	logrus.Info("Received SetDeviceTemperatureForEvent")
-	if deviceTemperature == nil || len(deviceTemperature.IpAddress) == 0 {
+//SetManagerDateTime ...
+func (s *Server) SetManagerDateTime(c context.Context, managerDateTime *manager.ManagerDateTime) (*empty.Empty, error) {
+	logrus.Info("Received SetManagerDateTime")
+	if managerDateTime == nil || len(managerDateTime.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := managerDateTime.IpAddress
+	authStr := managerDateTime.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setManagerDateTime(ipAddress, authStr, managerDateTime.DateTime, managerDateTime.DateTimeLocalOffset)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"DateTime":        managerDateTime.DateTime,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetManagerCertificate ...
+func (s *Server) GetManagerCertificate(c context.Context, managerCertificate *manager.ManagerCertificate) (*manager.ManagerCertificate, error) {
+	logrus.Info("Received GetManagerCertificate")
+	if managerCertificate == nil || len(managerCertificate.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := managerCertificate.IpAddress
+	authStr := managerCertificate.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	certificate, statusCode, err := s.getManagerCertificate(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	managerCertificate.CertificateUri = certificate.OdataID
+	managerCertificate.Subject = certificate.Subject
+	managerCertificate.Issuer = certificate.Issuer
+	managerCertificate.ValidNotBefore = certificate.ValidNotBefore
+	managerCertificate.ValidNotAfter = certificate.ValidNotAfter
+	return managerCertificate, nil
+}
+
+//ReplaceManagerCertificate ...
+func (s *Server) ReplaceManagerCertificate(c context.Context, managerCertificate *manager.ManagerCertificate) (*empty.Empty, error) {
+	logrus.Info("Received ReplaceManagerCertificate")
+	if managerCertificate == nil || len(managerCertificate.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := managerCertificate.IpAddress
+	authStr := managerCertificate.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.replaceManagerCertificate(ipAddress, authStr, managerCertificate.CertificateString)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetHealthRollup ...
+func (s *Server) GetHealthRollup(c context.Context, healthRollup *manager.HealthRollup) (*manager.HealthRollup, error) {
+	logrus.Info("Received GetHealthRollup")
+	if healthRollup == nil || len(healthRollup.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := healthRollup.IpAddress
+	authStr := healthRollup.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	healths, statusCode, err := s.getHealthRollup(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, health := range healths {
+		healthRollup.Resources = append(healthRollup.Resources, &manager.ResourceHealth{Kind: health.Kind, OdataId: health.OdataID, Health: health.Health, HealthRollup: health.HealthRollup})
+	}
+	return healthRollup, nil
+}
+
+//GetNetworkPortStats ...
+func (s *Server) GetNetworkPortStats(c context.Context, networkPortStats *manager.NetworkPortStats) (*manager.NetworkPortStats, error) {
+	logrus.Info("Received GetNetworkPortStats")
+	if networkPortStats == nil || len(networkPortStats.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := networkPortStats.IpAddress
+	authStr := networkPortStats.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	stats, statusCode, err := s.getNetworkPortStats(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, stat := range stats {
+		networkPortStats.Ports = append(networkPortStats.Ports, &manager.PortStats{OdataId: stat.OdataID, RxBytes: stat.RXBytes, TxBytes: stat.TXBytes, RxErrors: stat.RXErrors, TxErrors: stat.TXErrors})
+	}
+	return networkPortStats, nil
+}
+
+//GetThermalRedundancy ...
+func (s *Server) GetThermalRedundancy(c context.Context, thermalRedundancy *manager.ThermalRedundancy) (*manager.ThermalRedundancy, error) {
+	logrus.Info("Received GetThermalRedundancy")
+	if thermalRedundancy == nil || len(thermalRedundancy.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := thermalRedundancy.IpAddress
+	authStr := thermalRedundancy.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	redundancies, statusCode, err := s.getThermalRedundancy(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	thermalRedundancy.Redundancies = toRedundancyGroups(redundancies)
+	return thermalRedundancy, nil
+}
+
+func toRedundancyGroups(redundancies []redundancyGroup) (groups []*manager.RedundancyGroup) {
+	for _, redundancy := range redundancies {
+		groups = append(groups, &manager.RedundancyGroup{OdataId: redundancy.OdataID, Name: redundancy.Name, Mode: redundancy.Mode,
+			MinNumNeeded: redundancy.MinNumNeeded, MaxNumSupported: redundancy.MaxNumSupported, MemberCount: redundancy.MemberCount,
+			State: redundancy.State, Health: redundancy.Health, Status: getRedundancyStatus(redundancy)})
+	}
+	return groups
+}
+
+//GetPowerRedundancy ...
+func (s *Server) GetPowerRedundancy(c context.Context, powerRedundancy *manager.PowerRedundancy) (*manager.PowerRedundancy, error) {
+	logrus.Info("Received GetPowerRedundancy")
+	if powerRedundancy == nil || len(powerRedundancy.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := powerRedundancy.IpAddress
+	authStr := powerRedundancy.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	redundancies, supplies, statusCode, err := s.getPowerRedundancy(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	powerRedundancy.Redundancies = toRedundancyGroups(redundancies)
+	for _, supply := range supplies {
+		powerRedundancy.PowerSupplies = append(powerRedundancy.PowerSupplies, &manager.PowerSupplyInput{OdataId: supply.OdataID, Name: supply.Name,
+			LineInputVoltageType: supply.LineInputVoltageType, LineInputVoltage: supply.LineInputVoltage, PowerInputWatts: supply.PowerInputWatts,
+			State: supply.State, Health: supply.Health})
+	}
+	return powerRedundancy, nil
+}
+
+//GetEventServiceStatus ...
+func (s *Server) GetEventServiceStatus(c context.Context, eventServiceStatus *manager.EventServiceStatus) (*manager.EventServiceStatus, error) {
+	logrus.Info("Received GetEventServiceStatus")
+	if eventServiceStatus == nil || len(eventServiceStatus.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := eventServiceStatus.IpAddress
+	authStr := eventServiceStatus.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	enabled, health, statusCode, err := s.getEventServiceStatus(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	eventServiceStatus.ServiceEnabled = enabled
+	eventServiceStatus.Health = health
+	return eventServiceStatus, nil
+}
+
+//SetEventServiceEnabled ...
+func (s *Server) SetEventServiceEnabled(c context.Context, eventServiceStatus *manager.EventServiceStatus) (*empty.Empty, error) {
+	logrus.Info("Received SetEventServiceEnabled")
+	if eventServiceStatus == nil || len(eventServiceStatus.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := eventServiceStatus.IpAddress
+	authStr := eventServiceStatus.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setEventServiceEnabled(ipAddress, authStr, eventServiceStatus.ServiceEnabled)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"ServiceEnabled":  eventServiceStatus.ServiceEnabled,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetBootProgress ...
+func (s *Server) GetBootProgress(c context.Context, bootProgressData *manager.BootProgress) (*manager.BootProgress, error) {
+	logrus.Info("Received GetBootProgress")
+	if bootProgressData == nil || len(bootProgressData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := bootProgressData.IpAddress
+	authStr := bootProgressData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	progress, statusCode, err := s.getBootProgress(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	bootProgressData.LastState = progress.LastState
+	bootProgressData.OemLastState = progress.OemLastState
+	bootProgressData.LastStateTime = progress.LastStateTime
+	bootProgressData.PowerState = progress.PowerState
+	return bootProgressData, nil
+}
+
+//GetPCIeDevices ...
+func (s *Server) GetPCIeDevices(c context.Context, pcieDevices *manager.PCIeDevices) (*manager.PCIeDevices, error) {
+	logrus.Info("Received GetPCIeDevices")
+	if pcieDevices == nil || len(pcieDevices.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := pcieDevices.IpAddress
+	authStr := pcieDevices.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	devices, statusCode, err := s.getPCIeDevices(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, device := range devices {
+		pcieDevices.Devices = append(pcieDevices.Devices, &manager.PCIeDevice{OdataId: device.OdataID, Name: device.Name, DeviceType: device.DeviceType,
+			DeviceClass: device.DeviceClass, Manufacturer: device.Manufacturer, Model: device.Model, FirmwareVersion: device.FirmwareVersion,
+			State: device.State, Health: device.Health})
+	}
+	return pcieDevices, nil
+}
+
+//GetAcceleratorTelemetry ...
+func (s *Server) GetAcceleratorTelemetry(c context.Context, telemetry *manager.AcceleratorTelemetry) (*manager.AcceleratorTelemetry, error) {
+	logrus.Info("Received GetAcceleratorTelemetry")
+	if telemetry == nil || len(telemetry.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := telemetry.IpAddress
+	authStr := telemetry.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	accelerators, statusCode, err := s.getAcceleratorTelemetry(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, gpu := range accelerators {
+		telemetry.Accelerators = append(telemetry.Accelerators, &manager.Accelerator{OdataId: gpu.OdataID, Name: gpu.Name, ProcessorType: gpu.ProcessorType,
+			Model: gpu.Model, State: gpu.State, Health: gpu.Health, TemperatureCelsius: gpu.TemperatureCelsius, PowerWatts: gpu.PowerWatts,
+			UtilizationPercent: gpu.UtilizationPercent, OemMetrics: gpu.OemMetrics})
+	}
+	return telemetry, nil
+}
+
+//GetSerialConsoleStatus ...
+func (s *Server) GetSerialConsoleStatus(c context.Context, consoleStatus *manager.SerialConsoleStatus) (*manager.SerialConsoleStatus, error) {
+	logrus.Info("Received GetSerialConsoleStatus")
+	if consoleStatus == nil || len(consoleStatus.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := consoleStatus.IpAddress
+	authStr := consoleStatus.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	consoles, statusCode, err := s.getConsoleStatus(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, console := range consoles {
+		info := &manager.ConsoleInfo{Name: console.Name, ServiceEnabled: console.ServiceEnabled, MaxConcurrentSessions: console.MaxConcurrentSessions}
+		for _, connection := range console.Connections {
+			info.Connections = append(info.Connections, &manager.ConsoleConnection{ConnectType: connection.ConnectType, Port: connection.Port})
+		}
+		consoleStatus.Consoles = append(consoleStatus.Consoles, info)
+	}
+	return consoleStatus, nil
+}
+
+//SetSerialConsoleEnabled ...
+func (s *Server) SetSerialConsoleEnabled(c context.Context, consoleStatus *manager.SerialConsoleStatus) (*empty.Empty, error) {
+	logrus.Info("Received SetSerialConsoleEnabled")
+	if consoleStatus == nil || len(consoleStatus.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := consoleStatus.IpAddress
+	authStr := consoleStatus.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setConsoleEnabled(ipAddress, authStr, consoleStatus.Console, consoleStatus.ServiceEnabled)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Console":         consoleStatus.Console,
+			"ServiceEnabled":  consoleStatus.ServiceEnabled,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//CheckRedfishConformance ...
+func (s *Server) CheckRedfishConformance(c context.Context, conformance *manager.RedfishConformance) (*manager.RedfishConformance, error) {
+	logrus.Info("Received CheckRedfishConformance")
+	if conformance == nil || len(conformance.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := conformance.IpAddress
+	authStr := conformance.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	results, statusCode, err := s.checkRedfishConformance(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, result := range results {
+		conformance.Results = append(conformance.Results, &manager.ConformanceResult{Name: result.Name, Uri: result.URI, Status: result.Status, Detail: result.Detail})
+	}
+	return conformance, nil
+}
+
+//GetManufacturingInfo ...
+func (s *Server) GetManufacturingInfo(c context.Context, manufacturingInfo *manager.ManufacturingInfo) (*manager.ManufacturingInfo, error) {
+	logrus.Info("Received GetManufacturingInfo")
+	if manufacturingInfo == nil || len(manufacturingInfo.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := manufacturingInfo.IpAddress
+	authStr := manufacturingInfo.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	info, statusCode, err := s.getManufacturingInfo(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	manufacturingInfo.Manufacturer = info.Manufacturer
+	manufacturingInfo.Model = info.Model
+	manufacturingInfo.SerialNumber = info.SerialNumber
+	manufacturingInfo.PartNumber = info.PartNumber
+	manufacturingInfo.Sku = info.SKU
+	manufacturingInfo.ManufactureDate = info.ManufactureDate
+	manufacturingInfo.Warranty = info.Warranty
+	return manufacturingInfo, nil
+}
+
+//SetIpmiOverLan ...
+func (s *Server) SetIpmiOverLan(c context.Context, ipmiOverLan *manager.IpmiOverLan) (*empty.Empty, error) {
+	logrus.Info("Received SetIpmiOverLan")
+	if ipmiOverLan == nil || len(ipmiOverLan.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := ipmiOverLan.IpAddress
+	authStr := ipmiOverLan.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setIpmiOverLan(ipAddress, authStr, ipmiOverLan.Enabled)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Enabled":         ipmiOverLan.Enabled,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//SetMaintenanceWindow ...
+func (s *Server) SetMaintenanceWindow(c context.Context, maintenanceWindow *manager.MaintenanceWindow) (*empty.Empty, error) {
+	logrus.Info("Received SetMaintenanceWindow")
+	if maintenanceWindow == nil || len(maintenanceWindow.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := maintenanceWindow.IpAddress
+	authStr := maintenanceWindow.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setMaintenanceWindow(ipAddress, maintenanceWindow.Start, maintenanceWindow.End)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Start":           maintenanceWindow.Start,
+			"End":             maintenanceWindow.End,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetPhysicalLocation ...
+func (s *Server) GetPhysicalLocation(c context.Context, physicalLocation *manager.PhysicalLocation) (*manager.PhysicalLocation, error) {
+	logrus.Info("Received GetPhysicalLocation")
+	if physicalLocation == nil || len(physicalLocation.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := physicalLocation.IpAddress
+	authStr := physicalLocation.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	locations, statusCode, err := s.getPhysicalLocation(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	for _, location := range locations {
+		physicalLocation.Locations = append(physicalLocation.Locations, &manager.ChassisLocation{OdataId: location.OdataID, Name: location.Name,
+			ServiceLabel: location.ServiceLabel, LocationType: location.LocationType, LocationOrdinalValue: location.LocationOrdinalValue,
+			Row: location.Row, Rack: location.Rack, RackOffsetUnits: location.RackOffsetUnits, RackOffset: location.RackOffset, ContainedBy: location.ContainedBy})
+	}
+	return physicalLocation, nil
+}
+
+//GetWatchdog ...
+func (s *Server) GetWatchdog(c context.Context, watchdogData *manager.Watchdog) (*manager.Watchdog, error) {
+	logrus.Info("Received GetWatchdog")
+	if watchdogData == nil || len(watchdogData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := watchdogData.IpAddress
+	authStr := watchdogData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	watchdog, statusCode, err := s.getWatchdog(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	watchdogData.FunctionEnabled = watchdog.FunctionEnabled
+	watchdogData.TimeoutAction = watchdog.TimeoutAction
+	watchdogData.TimeoutActionAllowed = watchdog.TimeoutActionAllowed
+	watchdogData.WarningAction = watchdog.WarningAction
+	watchdogData.TimeoutSeconds = watchdog.TimeoutSeconds
+	watchdogData.State = watchdog.State
+	watchdogData.Health = watchdog.Health
+	return watchdogData, nil
+}
+
+//SetWatchdog ...
+func (s *Server) SetWatchdog(c context.Context, watchdogData *manager.Watchdog) (*empty.Empty, error) {
+	logrus.Info("Received SetWatchdog")
+	if watchdogData == nil || len(watchdogData.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := watchdogData.IpAddress
+	authStr := watchdogData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setWatchdog(ipAddress, authStr, watchdogData.FunctionEnabled, watchdogData.TimeoutAction, watchdogData.TimeoutSeconds)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"FunctionEnabled": watchdogData.FunctionEnabled,
+			"TimeoutAction":   watchdogData.TimeoutAction,
+			"TimeoutSeconds":  watchdogData.TimeoutSeconds,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetFanControl ...
+func (s *Server) GetFanControl(c context.Context, fanControlData *manager.FanControl) (*manager.FanControl, error) {
+	logrus.Info("Received GetFanControl")
+	if fanControlData == nil || len(fanControlData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := fanControlData.IpAddress
+	authStr := fanControlData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	control, statusCode, err := s.getFanControl(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	fanControlData.Mode = control.Mode
+	fanControlData.ModeAllowed = control.ModeAllowed
+	fanControlData.PwmPercent = uint32(control.PwmPercent)
+	fanControlData.PwmSupported = control.PwmOemName != ""
+	fanControlData.ManualSupported = control.ManualSupported
+	for _, fan := range control.Fans {
+		fanControlData.Fans = append(fanControlData.Fans, &manager.FanSpeed{Name: fan.Name, Reading: fan.Reading, Units: fan.Units})
+	}
+	return fanControlData, nil
+}
+
+//SetFanControl ...
+func (s *Server) SetFanControl(c context.Context, fanControlData *manager.FanControl) (*empty.Empty, error) {
+	logrus.Info("Received SetFanControl")
+	if fanControlData == nil || len(fanControlData.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := fanControlData.IpAddress
+	authStr := fanControlData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setFanControl(ipAddress, authStr, fanControlData.Mode, fanControlData.PwmPercent)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Mode":            fanControlData.Mode,
+			"PwmPercent":      fanControlData.PwmPercent,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetBootPolicy ...
+func (s *Server) GetBootPolicy(c context.Context, bootPolicyData *manager.BootPolicy) (*manager.BootPolicy, error) {
+	logrus.Info("Received GetBootPolicy")
+	if bootPolicyData == nil || len(bootPolicyData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := bootPolicyData.IpAddress
+	authStr := bootPolicyData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	policy, statusCode, err := s.getBootPolicy(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	bootPolicyData.AutomaticRetryConfig = policy.AutomaticRetryConfig
+	bootPolicyData.PowerRestorePolicy = policy.PowerRestorePolicy
+	bootPolicyData.AutomaticRetryConfigAllowed = policy.AutomaticRetryConfigAllowed
+	bootPolicyData.PowerRestorePolicyAllowed = policy.PowerRestorePolicyAllowed
+	return bootPolicyData, nil
+}
+
+//SetBootPolicy ...
+func (s *Server) SetBootPolicy(c context.Context, bootPolicyData *manager.BootPolicy) (*empty.Empty, error) {
+	logrus.Info("Received SetBootPolicy")
+	if bootPolicyData == nil || len(bootPolicyData.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := bootPolicyData.IpAddress
+	authStr := bootPolicyData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setBootPolicy(ipAddress, authStr, bootPolicyData.AutomaticRetryConfig, bootPolicyData.PowerRestorePolicy)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port":      ipAddress,
+			"AutomaticRetryConfig": bootPolicyData.AutomaticRetryConfig,
+			"PowerRestorePolicy":   bootPolicyData.PowerRestorePolicy,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//ClearBootOverride ...
+func (s *Server) ClearBootOverride(c context.Context, bootOverrideData *manager.BootOverride) (*manager.BootOverride, error) {
+	logrus.Info("Received ClearBootOverride")
+	if bootOverrideData == nil || len(bootOverrideData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := bootOverrideData.IpAddress
+	authStr := bootOverrideData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	override, statusCode, err := s.clearBootOverride(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	bootOverrideData.Enabled = override.Enabled
+	bootOverrideData.Target = override.Target
+	bootOverrideData.Mode = override.Mode
+	return bootOverrideData, nil
+}
+
+//GetBootOrder ...
+func (s *Server) GetBootOrder(c context.Context, bootOrderData *manager.BootOrder) (*manager.BootOrder, error) {
+	logrus.Info("Received GetBootOrder")
+	if bootOrderData == nil || len(bootOrderData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := bootOrderData.IpAddress
+	authStr := bootOrderData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	order, statusCode, err := s.getBootOrder(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	bootOrderData.Options = nil
+	for _, option := range order {
+		bootOrderData.Options = append(bootOrderData.Options, &manager.BootOption{
+			Reference:   option.Reference,
+			DisplayName: option.DisplayName,
+			OdataId:     option.OdataID,
+		})
+	}
+	return bootOrderData, nil
+}
+
+//ExportBiosSettings ...
+func (s *Server) ExportBiosSettings(c context.Context, biosData *manager.BiosSettings) (*manager.BiosSettings, error) {
+	logrus.Info("Received ExportBiosSettings")
+	if biosData == nil || len(biosData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := biosData.IpAddress
+	authStr := biosData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	attributes, attributeRegistry, statusCode, err := s.exportBiosSettings(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	biosData.Attributes = attributes
+	biosData.AttributeRegistry = attributeRegistry
+	return biosData, nil
+}
+
+//ImportBiosSettings ...
+func (s *Server) ImportBiosSettings(c context.Context, biosData *manager.BiosSettings) (*manager.BiosSettings, error) {
+	logrus.Info("Received ImportBiosSettings")
+	if biosData == nil || len(biosData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := biosData.IpAddress
+	authStr := biosData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	result, statusCode, err := s.importBiosSettings(ipAddress, authStr, biosData.Attributes)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	biosData.Attributes = ""
+	biosData.AttributeRegistry = result.AttributeRegistry
+	biosData.SettingsOdataId = result.SettingsOdataID
+	biosData.Staged = result.Staged
+	biosData.Unchanged = uint32(result.Unchanged)
+	biosData.Rejected = result.Rejected
+	return biosData, nil
+}
+
+//GetSystemCapacity ...
+func (s *Server) GetSystemCapacity(c context.Context, capacityData *manager.SystemCapacity) (*manager.SystemCapacity, error) {
+	logrus.Info("Received GetSystemCapacity")
+	if capacityData == nil || len(capacityData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := capacityData.IpAddress
+	authStr := capacityData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	capacity, statusCode, err := s.getSystemCapacity(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	capacityData.SystemCount = capacity.SystemCount
+	capacityData.TotalMemoryGiB = capacity.TotalMemoryGiB
+	capacityData.LogicalProcessorCount = capacity.LogicalProcessorCount
+	return capacityData, nil
+}
+
+//GetActionAllowableValues ...
+func (s *Server) GetActionAllowableValues(c context.Context, actionData *manager.ActionAllowableValues) (*manager.ActionAllowableValues, error) {
+	logrus.Info("Received GetActionAllowableValues")
+	if actionData == nil || len(actionData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := actionData.IpAddress
+	authStr := actionData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	actions, statusCode, err := s.getActionAllowableValues(ipAddress, authStr, actionData.Action)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"Action":          actionData.Action,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	actionData.Actions = nil
+	for _, action := range actions {
+		redfishAction := &manager.RedfishAction{Name: action.Name, Target: action.Target}
+		for _, parameter := range action.Parameters {
+			redfishAction.Parameters = append(redfishAction.Parameters, &manager.ActionParameter{Name: parameter.Name, AllowableValues: parameter.AllowableValues})
+		}
+		actionData.Actions = append(actionData.Actions, redfishAction)
+	}
+	return actionData, nil
+}
+
+//GetDeviceTemperatures ...
+func (s *Server) GetDeviceTemperatures(c context.Context, deviceTemperature *manager.DeviceTemperature) (*manager.DeviceTemperature, error) {
+	logrus.Info("Received GetDeviceTemperatures")
+	if deviceTemperature == nil || len(deviceTemperature.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := deviceTemperature.IpAddress
+	var authStr string
+	authStr = deviceTemperature.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	deviceTemp, statusCode, err := s.getDeviceTemperature(ipAddress, authStr)
+	if err != nil && statusCode != http.StatusOK {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	deviceTempData := new(manager.DeviceTemperature)
+	deviceTempData.TempData = deviceTemp
+	return deviceTempData, nil
+}
+
+//GetTemperatureThresholds ...
+func (s *Server) GetTemperatureThresholds(c context.Context, thresholdData *manager.TemperatureThresholds) (*manager.TemperatureThresholds, error) {
+	logrus.Info("Received GetTemperatureThresholds")
+	if thresholdData == nil || len(thresholdData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := thresholdData.IpAddress
+	authStr := thresholdData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	thresholds, statusCode, err := s.getTemperatureThresholds(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	thresholdData.Thresholds = nil
+	for _, threshold := range thresholds {
+		thresholdData.Thresholds = append(thresholdData.Thresholds, &manager.TemperatureThreshold{
+			Chassis:                threshold.Chassis,
+			MemberID:               threshold.MemberID,
+			Name:                   threshold.Name,
+			ReadingCelsius:         threshold.ReadingCelsius,
+			UpperThresholdCritical: threshold.UpperThresholdCritical,
+			UpperThresholdFatal:    threshold.UpperThresholdFatal,
+			HeadroomCelsius:        threshold.HeadroomCelsius,
+		})
+	}
+	return thresholdData, nil
+}
+
+//SetDeviceTemperatureForEvent ...
+func (s *Server) SetDeviceTemperatureForEvent(c context.Context, deviceTemperature *manager.DeviceTemperature) (*empty.Empty, error) {
+	logrus.Info("Received SetDeviceTemperatureForEvent")
+	if deviceTemperature == nil || len(deviceTemperature.IpAddress) == 0 {
 		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrEventTemperInvalid.String())
 	}
 	ipAddress := deviceTemperature.IpAddress
@@ -1120,3 +2906,122 @@ func (s *Server) SetDeviceTemperatureForEvent(c context.Context, deviceTemperatu
 	}
 	return &empty.Empty{}, nil
 }
+
+//GetPowerCap ...
+func (s *Server) GetPowerCap(c context.Context, powerCapData *manager.PowerCap) (*manager.PowerCap, error) {
+	logrus.Info("Received GetPowerCap")
+	if powerCapData == nil || len(powerCapData.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := powerCapData.IpAddress
+	authStr := powerCapData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	powerLimit, statusCode, err := s.getPowerCap(ipAddress, authStr)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	powerCapData.LimitInWatts = uint32(powerLimit.LimitInWatts)
+	powerCapData.MinWatts = uint32(powerLimit.MinWatts)
+	powerCapData.MaxWatts = uint32(powerLimit.MaxWatts)
+	powerCapData.Active = powerLimit.Active
+	powerCapData.CorrectionInMs = powerLimit.CorrectionInMs
+	powerCapData.LimitException = powerLimit.LimitException
+	return powerCapData, nil
+}
+
+//SetPowerCap ...
+func (s *Server) SetPowerCap(c context.Context, powerCapData *manager.PowerCap) (*empty.Empty, error) {
+	logrus.Info("Received SetPowerCap")
+	if powerCapData == nil || len(powerCapData.IpAddress) == 0 {
+		return &empty.Empty{}, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := powerCapData.IpAddress
+	authStr := powerCapData.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus", "userPrivilegeAdmin"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return &empty.Empty{}, err
+		}
+	}
+	statusCode, err := s.setPowerCap(ipAddress, authStr, powerCapData.LimitInWatts)
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+			"LimitInWatts":    powerCapData.LimitInWatts,
+		}).Error(errStatus.Message())
+		return &empty.Empty{}, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	return &empty.Empty{}, nil
+}
+
+//GetPollStatus ...
+func (s *Server) GetPollStatus(c context.Context, pollStatus *manager.PollStatus) (*manager.PollStatus, error) {
+	logrus.Info("Received GetPollStatus")
+	if pollStatus == nil || len(pollStatus.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := pollStatus.IpAddress
+	authStr := pollStatus.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	dev := s.devicemap[ipAddress]
+	dev.PollStateLock.Lock()
+	if !dev.LastPollAt.IsZero() {
+		pollStatus.LastAttempt = dev.LastPollAt.Unix()
+	}
+	if !dev.LastPollOKAt.IsZero() {
+		pollStatus.LastSuccess = dev.LastPollOKAt.Unix()
+	}
+	pollStatus.LastError = dev.LastPollError
+	pollStatus.ConsecutiveFailures = uint32(dev.PollFailures)
+	dev.PollStateLock.Unlock()
+	pollStatus.State = s.getDevicePollState(ipAddress)
+	pollStatus.QueryState = dev.QueryState
+	return pollStatus, nil
+}
+
+//GetPowerHistory ...
+func (s *Server) GetPowerHistory(c context.Context, powerHistory *manager.PowerHistory) (*manager.PowerHistory, error) {
+	logrus.Info("Received GetPowerHistory")
+	if powerHistory == nil || len(powerHistory.IpAddress) == 0 {
+		return nil, status.Errorf(http.StatusBadRequest, ErrDeviceData.String())
+	}
+	ipAddress := powerHistory.IpAddress
+	authStr := powerHistory.UserOrToken
+	funcs := []string{"checkIPAddress", "checkRegistered", "loginStatus", "userStatus"}
+	for _, f := range funcs {
+		if _, err := s.getFunctionsResult(f, ipAddress, authStr, ""); err != nil {
+			return nil, err
+		}
+	}
+	history, statusCode, err := s.getPowerHistory(ipAddress)
+	if err != nil && statusCode != http.StatusOK {
+		errStatus, _ := status.FromError(err)
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": ipAddress,
+		}).Error(errStatus.Message())
+		return nil, status.Errorf(codes.Code(statusCode), errStatus.Message())
+	}
+	devicePowerHistory := new(manager.PowerHistory)
+	for _, sample := range history {
+		devicePowerHistory.Samples = append(devicePowerHistory.Samples, &manager.PowerSample{
+			Timestamp:          sample.Timestamp.Unix(),
+			PowerConsumedWatts: sample.PowerConsumedWatts,
+		})
+	}
+	return devicePowerHistory, nil
+}
@@ -0,0 +1,73 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//resourceHealth holds the Redfish Status health of one system, chassis or manager
+type resourceHealth struct {
+	Kind         string
+	OdataID      string
+	Health       string
+	HealthRollup string
+}
+
+/* getHealthRollup() returns the Status.Health and Status.HealthRollup of every system, chassis and manager of the device
+ */
+func (s *Server) getHealthRollup(deviceIPAddress, authStr string) (healths []resourceHealth, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	collections := [][]string{{"system", RfSystems}, {"chassis", RfChassis}, {"manager", RfManager}}
+	for _, collection := range collections {
+		collectionData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, collection[1], userAuthData)
+		if collectionData == nil {
+			continue
+		}
+		for _, odataID := range s.getRedfishDeviceData(collectionData, 2, "@odata.id") {
+			resourceData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, odataID, userAuthData)
+			if resourceData == nil {
+				continue
+			}
+			health := resourceHealth{Kind: collection[0], OdataID: odataID}
+			resourceStatus, _ := resourceData["Status"].(map[string]interface{})
+			health.Health, _ = resourceStatus["Health"].(string)
+			health.HealthRollup, _ = resourceStatus["HealthRollup"].(string)
+			if health.HealthRollup == "" {
+				health.HealthRollup = health.Health
+			}
+			healths = append(healths, health)
+		}
+	}
+	if len(healths) == 0 {
+		logrus.Errorf(ErrGetHealthFailed.String())
+		return nil, http.StatusNotFound, errors.New(ErrGetHealthFailed.String())
+	}
+	return healths, http.StatusOK, nil
+}
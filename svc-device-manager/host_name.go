@@ -0,0 +1,141 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+const (
+	//maxHostNameLength ...
+	maxHostNameLength = 253
+	//maxHostNameLabelLength ...
+	maxHostNameLabelLength = 63
+)
+
+//hostNameInfo ...
+type hostNameInfo struct {
+	HostName  string
+	FQDN      string
+	Interface string
+}
+
+/* validateHostName() checks the host name against RFC 1123: dot separated labels of 1 to 63 letters, digits and
+ * hyphens that neither start nor end with a hyphen, at most 253 characters in total
+ */
+func validateHostName(hostName string) bool {
+	if len(hostName) == 0 || len(hostName) > maxHostNameLength {
+		return false
+	}
+	for _, label := range strings.Split(hostName, ".") {
+		if len(label) == 0 || len(label) > maxHostNameLabelLength || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/* findHostNameInterface() returns the first EthernetInterface of the first manager that reports a HostName
+ */
+func (s *Server) findHostNameInterface(deviceIPAddress, authStr string, userAuthData userAuth) (info hostNameInfo, found bool) {
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	if len(managerOdataIds) == 0 {
+		return info, false
+	}
+	managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData)
+	interfacesURI := getOdataID(managerData, "EthernetInterfaces")
+	if interfacesURI == "" {
+		return info, false
+	}
+	interfaces, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, interfacesURI, userAuthData)
+	for _, member := range s.getRedfishDeviceData(interfaces, 2, "@odata.id") {
+		interfaceData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, member, userAuthData)
+		hostName, ok := interfaceData["HostName"].(string)
+		if !ok {
+			continue
+		}
+		info.HostName = hostName
+		info.FQDN, _ = interfaceData["FQDN"].(string)
+		info.Interface = member
+		return info, true
+	}
+	return info, false
+}
+
+/* getHostName() reads the HostName and FQDN the manager EthernetInterface reports
+ */
+func (s *Server) getHostName(deviceIPAddress, authStr string) (info hostNameInfo, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return info, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	info, found := s.findHostNameInterface(deviceIPAddress, authStr, userAuthData)
+	if !found {
+		logrus.Errorf(ErrHostNameNotSupported.String())
+		return info, http.StatusNotImplemented, errors.New(ErrHostNameNotSupported.String())
+	}
+	return info, http.StatusOK, nil
+}
+
+/* setHostName() patches the HostName of the manager EthernetInterface and reads it back so the name the device
+ * applied is returned
+ */
+func (s *Server) setHostName(deviceIPAddress, authStr, hostName string) (info hostNameInfo, statusCode int, err error) {
+	if !validateHostName(hostName) {
+		logrus.Errorf(ErrHostNameInvalid.String(hostName))
+		return info, http.StatusBadRequest, errors.New(ErrHostNameInvalid.String(hostName))
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return info, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	info, found := s.findHostNameInterface(deviceIPAddress, authStr, userAuthData)
+	if !found {
+		logrus.Errorf(ErrHostNameNotSupported.String())
+		return info, http.StatusNotImplemented, errors.New(ErrHostNameNotSupported.String())
+	}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, info.Interface, userAuthData, map[string]interface{}{"HostName": hostName})
+	if statusCode != http.StatusOK && statusCode != http.StatusAccepted && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetHostNameFailed.String(hostName, strconv.Itoa(statusCode)))
+		return info, statusCode, errors.New(ErrSetHostNameFailed.String(hostName, strconv.Itoa(statusCode)))
+	}
+	interfaceData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, info.Interface, userAuthData)
+	if applied, ok := interfaceData["HostName"].(string); ok {
+		info.HostName = applied
+		info.FQDN, _ = interfaceData["FQDN"].(string)
+	} else {
+		info.HostName = hostName
+	}
+	return info, statusCode, nil
+}
@@ -28,8 +28,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"strconv"
+	"time"
 
 	logrus "github.com/sirupsen/logrus"
 )
@@ -71,8 +74,11 @@ func checkRedirect(req *http.Request, via []*http.Request) error {
 	return nil
 }
 
-func httpRedirction(request *http.Request) (client *http.Client, location string, shouldRedirect bool, err error) {
-	response, err := http.DefaultTransport.RoundTrip(request)
+func httpRedirction(request *http.Request, timeout time.Duration) (client *http.Client, location string, shouldRedirect bool, err error) {
+	waitRequestToken(request.URL.Host)
+	timeoutRequest, cancel := withRequestTimeout(request, timeout)
+	defer cancel()
+	response, err := getDeviceTransport(request.URL.Host).RoundTrip(timeoutRequest)
 	if response != nil {
 		defer response.Body.Close()
 	}
@@ -85,7 +91,9 @@ func httpRedirction(request *http.Request) (client *http.Client, location string
 		shouldRedirect = true
 	}
 	client = &http.Client{
+		Transport:     getDeviceTransport(request.URL.Host),
 		CheckRedirect: checkRedirect,
+		Timeout:       timeout,
 	}
 	return client, location, shouldRedirect, err
 }
@@ -103,6 +111,13 @@ func performHTTPRedirection(method string, client *http.Client, location string)
 }
 
 func getHTTPBodyByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth) (body []byte, statusCode int, err error) {
+	return getHTTPBodyByRfAPIWithTimeout(deviceIPAddress, RfAPI, userAuthData, 0)
+}
+
+/* getHTTPBodyByRfAPIWithTimeout() reads the Redfish API like getHTTPBodyByRfAPI, the GET fails when it takes longer
+ * than the timeout, 0 means no limit
+ */
+func getHTTPBodyByRfAPIWithTimeout(deviceIPAddress, RfAPI string, userAuthData userAuth, timeout time.Duration) (body []byte, statusCode int, err error) {
 	var request *http.Request
 	RfAPI = addSlashToTail(RfAPI)
 	var url string
@@ -119,7 +134,7 @@ func getHTTPBodyByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth) (b
 	addAuthHeader(request, userAuthData)
 	request.Header.Add("User-Agent", UserAgent)
 	request.Header.Add("Accept", Accept)
-	client, loc, shouldRedirect, err := httpRedirction(request)
+	client, loc, shouldRedirect, err := httpRedirction(request, timeout)
 	if err != nil {
 		return nil, http.StatusMisdirectedRequest, err
 	}
@@ -131,7 +146,8 @@ func getHTTPBodyByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth) (b
 			return nil, http.StatusNotAcceptable, err
 		}
 	} else {
-		response, err = http.DefaultClient.Do(request)
+		waitRequestToken(deviceIPAddress)
+		response, err = getTimeoutClient(deviceIPAddress, timeout).Do(request)
 		if response != nil {
 			defer response.Body.Close()
 		}
@@ -192,7 +208,8 @@ func postHTTPDataByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth, d
 	}
 	request.Header.Add("User-Agent", UserAgent)
 	request.Header.Add("Accept", Accept)
-	response, err = http.DefaultClient.Do(request)
+	waitRequestToken(deviceIPAddress)
+	response, err = getDeviceClient(deviceIPAddress).Do(request)
 	if err != nil {
 		logrus.Errorf(ErrHTTPPostDataFailed.String(err.Error()))
 		return nil, nil, http.StatusNotAcceptable, err
@@ -212,6 +229,59 @@ func postHTTPDataByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth, d
 	return response, result, response.StatusCode, err
 }
 
+/* postMultipartHTTPDataByRfAPI() posts a multipart/form-data request carrying the JSON "UpdateParameters" part and
+ * the "UpdateFile" part. The file is streamed to the device as it is read, it is never held in memory.
+ */
+func postMultipartHTTPDataByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth, parameters interface{}, fileName string, file io.Reader) (response *http.Response, statusCode int, err error) {
+	var url string
+	if RfProtocol != nil && RfProtocol[deviceIPAddress] != "" {
+		url = RfProtocol[deviceIPAddress] + deviceIPAddress + RfAPI
+	} else {
+		url = RfDefaultHttpsProtocol + deviceIPAddress + RfAPI
+	}
+	pipeReader, pipeWriter := io.Pipe()
+	multipartWriter := multipart.NewWriter(pipeWriter)
+	go func() {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="UpdateParameters"`)
+		header.Set("Content-Type", DefaultContentType)
+		part, err := multipartWriter.CreatePart(header)
+		if err == nil {
+			err = json.NewEncoder(part).Encode(parameters)
+		}
+		if err == nil {
+			part, err = multipartWriter.CreateFormFile("UpdateFile", fileName)
+		}
+		if err == nil {
+			_, err = io.Copy(part, file)
+		}
+		if err == nil {
+			err = multipartWriter.Close()
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+	request, err := http.NewRequest("POST", url, pipeReader)
+	if err != nil {
+		pipeReader.CloseWithError(err)
+		return nil, http.StatusBadRequest, err
+	}
+	request.Close = true
+	addAuthHeader(request, userAuthData)
+	request.Header.Add("Content-Type", multipartWriter.FormDataContentType())
+	request.Header.Add("User-Agent", UserAgent)
+	request.Header.Add("Accept", Accept)
+	waitRequestToken(deviceIPAddress)
+	response, err = getDeviceClient(deviceIPAddress).Do(request)
+	if err != nil {
+		pipeReader.CloseWithError(err)
+		logrus.Errorf(ErrHTTPPostDataFailed.String(err.Error()))
+		return nil, http.StatusNotAcceptable, err
+	}
+	defer response.Body.Close()
+	logrus.Infof("HTTP response status: %s", response.Status)
+	return response, response.StatusCode, nil
+}
+
 func patchHTTPDataByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth, data interface{}) (response *http.Response, body map[string]interface{}, statusCode int, err error) {
 	var request *http.Request
 	if data == nil {
@@ -231,7 +301,8 @@ func patchHTTPDataByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth,
 	}
 	request.Header.Add("User-Agent", UserAgent)
 	request.Header.Add("Accept", Accept)
-	response, err = http.DefaultClient.Do(request)
+	waitRequestToken(deviceIPAddress)
+	response, err = getDeviceClient(deviceIPAddress).Do(request)
 	if err != nil {
 		logrus.Errorf(ErrHTTPPatchDataFailed.String(err.Error()))
 		return response, nil, http.StatusNotAcceptable, err
@@ -266,7 +337,8 @@ func deleteHTTPDataByRfAPI(deviceIPAddress, RfAPI string, userAuthData userAuth,
 	addAuthHeader(request, userAuthData)
 	request.Header.Add("User-Agent", UserAgent)
 	request.Header.Add("Accept", Accept)
-	response, err = http.DefaultClient.Do(request)
+	waitRequestToken(deviceIPAddress)
+	response, err = getDeviceClient(deviceIPAddress).Do(request)
 	if response != nil {
 		defer response.Body.Close()
 	}
@@ -0,0 +1,85 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+/* findIpmiProperty() returns the patch body enabling or disabling IPMI-over-LAN in the manager NetworkProtocol,
+ * the standard IPMI protocol is used when present, else an IPMI object with ProtocolEnabled below an OEM vendor
+ */
+func findIpmiProperty(networkProtocol map[string]interface{}, enabled bool) map[string]interface{} {
+	setting := map[string]interface{}{"ProtocolEnabled": enabled}
+	if _, ok := networkProtocol["IPMI"].(map[string]interface{}); ok {
+		return map[string]interface{}{"IPMI": setting}
+	}
+	oem, _ := networkProtocol["Oem"].(map[string]interface{})
+	for vendor, vendorData := range oem {
+		vendorInfo, _ := vendorData.(map[string]interface{})
+		for _, name := range []string{"IPMI", "Ipmi", "IPMIOverLAN", "IpmiOverLan"} {
+			if ipmi, ok := vendorInfo[name].(map[string]interface{}); ok {
+				if _, ok := ipmi["ProtocolEnabled"].(bool); ok {
+					return map[string]interface{}{"Oem": map[string]interface{}{vendor: map[string]interface{}{name: setting}}}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+/* setIpmiOverLan() enables or disables IPMI-over-LAN of the first manager of the device
+ */
+func (s *Server) setIpmiOverLan(deviceIPAddress, authStr string, enabled bool) (statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	if len(managerOdataIds) == 0 {
+		logrus.Errorf(ErrIpmiNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrIpmiNotSupported.String())
+	}
+	managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData)
+	networkProtocolURI := getOdataID(managerData, "NetworkProtocol")
+	if networkProtocolURI == "" {
+		logrus.Errorf(ErrIpmiNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrIpmiNotSupported.String())
+	}
+	networkProtocol, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, networkProtocolURI, userAuthData)
+	ipmiInfo := findIpmiProperty(networkProtocol, enabled)
+	if ipmiInfo == nil {
+		logrus.Errorf(ErrIpmiNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrIpmiNotSupported.String())
+	}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, networkProtocolURI, userAuthData, ipmiInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetIpmiFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrSetIpmiFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
@@ -0,0 +1,127 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+/* parseJSONPath() splits a JSON path such as "$.PowerControl[0].PowerConsumedWatts" into its member names
+ * and array indexes, "*" selects every member or element
+ */
+func parseJSONPath(path string) (steps []string, err error) {
+	rest := strings.TrimPrefix(path, "$")
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, errors.New(ErrJSONPathInvalid.String(path))
+			}
+			steps = append(steps, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, errors.New(ErrJSONPathInvalid.String(path))
+			}
+			index := strings.Trim(rest[1:end], "'\"")
+			if index == "" {
+				return nil, errors.New(ErrJSONPathInvalid.String(path))
+			}
+			steps = append(steps, index)
+			rest = rest[end+1:]
+		default:
+			if len(steps) != 0 || strings.HasPrefix(path, "$") {
+				return nil, errors.New(ErrJSONPathInvalid.String(path))
+			}
+			rest = "." + rest
+		}
+	}
+	return steps, nil
+}
+
+/* matchJSONPath() returns the values of data selected by the path steps
+ */
+func matchJSONPath(data interface{}, steps []string) []interface{} {
+	if len(steps) == 0 {
+		return []interface{}{data}
+	}
+	matches := []interface{}{}
+	switch value := data.(type) {
+	case map[string]interface{}:
+		if steps[0] == "*" {
+			for _, member := range value {
+				matches = append(matches, matchJSONPath(member, steps[1:])...)
+			}
+		} else if member, ok := value[steps[0]]; ok {
+			matches = append(matches, matchJSONPath(member, steps[1:])...)
+		}
+	case []interface{}:
+		if steps[0] == "*" {
+			for _, element := range value {
+				matches = append(matches, matchJSONPath(element, steps[1:])...)
+			}
+		} else if index, err := strconv.Atoi(steps[0]); err == nil && index >= 0 && index < len(value) {
+			matches = append(matches, matchJSONPath(value[index], steps[1:])...)
+		}
+	}
+	return matches
+}
+
+/* extractJSONPath() applies a JSON path to every JSON document of deviceData and returns the matched values
+ */
+func extractJSONPath(deviceData []string, path string) (retData []string, statusCode int, err error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		logrus.Errorf(err.Error())
+		return nil, http.StatusBadRequest, err
+	}
+	for _, document := range deviceData {
+		var data interface{}
+		if err := json.Unmarshal([]byte(document), &data); err != nil {
+			logrus.Errorf(ErrConvertData.String(err.Error()))
+			continue
+		}
+		for _, match := range matchJSONPath(data, steps) {
+			matchData, err := json.Marshal(match)
+			if err == nil {
+				retData = append(retData, string(matchData))
+			}
+		}
+	}
+	if len(retData) == 0 {
+		logrus.Errorf(ErrJSONPathNoMatch.String(path))
+		return nil, http.StatusNotFound, errors.New(ErrJSONPathNoMatch.String(path))
+	}
+	return retData, http.StatusOK, nil
+}
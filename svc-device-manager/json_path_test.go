@@ -0,0 +1,84 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		steps   []string
+		wantErr bool
+	}{
+		{"$.PowerControl[0].PowerConsumedWatts", []string{"PowerControl", "0", "PowerConsumedWatts"}, false},
+		{"PowerControl[0].PowerConsumedWatts", []string{"PowerControl", "0", "PowerConsumedWatts"}, false},
+		{"$.Fans[*].Reading", []string{"Fans", "*", "Reading"}, false},
+		{"$['Status'].Health", []string{"Status", "Health"}, false},
+		{"$.Oem.*", []string{"Oem", "*"}, false},
+		{"$", nil, false},
+		{"$..Name", nil, true},
+		{"$.Fans[0", nil, true},
+		{"$.Fans[]", nil, true},
+		{"$Fans", nil, true},
+	}
+	for _, test := range tests {
+		steps, err := parseJSONPath(test.path)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseJSONPath(%q) error %v, want error %v", test.path, err, test.wantErr)
+			continue
+		}
+		if !test.wantErr && !reflect.DeepEqual(steps, test.steps) {
+			t.Errorf("parseJSONPath(%q) = %q, want %q", test.path, steps, test.steps)
+		}
+	}
+}
+
+func TestMatchJSONPath(t *testing.T) {
+	var data interface{}
+	document := `{"PowerControl":[{"PowerConsumedWatts":120},{"PowerConsumedWatts":80}],"Status":{"Health":"OK"}}`
+	if err := json.Unmarshal([]byte(document), &data); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		steps []string
+		want  []interface{}
+	}{
+		{[]string{"PowerControl", "0", "PowerConsumedWatts"}, []interface{}{120.0}},
+		{[]string{"PowerControl", "*", "PowerConsumedWatts"}, []interface{}{120.0, 80.0}},
+		{[]string{"Status", "Health"}, []interface{}{"OK"}},
+		{[]string{"Status", "*"}, []interface{}{"OK"}},
+		{[]string{"PowerControl", "2", "PowerConsumedWatts"}, []interface{}{}},
+		{[]string{"PowerControl", "-1"}, []interface{}{}},
+		{[]string{"Status", "0"}, []interface{}{}},
+		{[]string{"Missing"}, []interface{}{}},
+		{nil, []interface{}{data}},
+	}
+	for _, test := range tests {
+		if got := matchJSONPath(data, test.steps); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("matchJSONPath(%q) = %v, want %v", test.steps, got, test.want)
+		}
+	}
+}
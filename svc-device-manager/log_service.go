@@ -26,6 +26,8 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	logrus "github.com/sirupsen/logrus"
 )
@@ -33,23 +35,78 @@ import (
 const (
 	//RfManager ...
 	RfManager = "/redfish/v1/Managers/"
+	//RfSystems ...
+	RfSystems = "/redfish/v1/Systems/"
 )
 
+type logServiceInfo struct {
+	ID             string
+	OdataID        string
+	ServiceEnabled bool
+}
+
+/* listDeviceLogServices() returns the LogServices exposed under Managers and Systems, managers first
+ */
+func (s *Server) listDeviceLogServices(deviceIPAddress, authStr string) (services []logServiceInfo, statusCode int, err error) {
+	for _, collection := range []string{RfManager, RfSystems} {
+		members, _, _ := s.getDeviceData(deviceIPAddress, collection, authStr, 2, "@odata.id")
+		for _, member := range members {
+			logServices, _, _ := s.getDeviceData(deviceIPAddress, member+"/LogServices", authStr, 2, "@odata.id")
+			for _, logService := range logServices {
+				logServiceID, _, _ := s.getDeviceData(deviceIPAddress, logService, authStr, 1, "Id")
+				if len(logServiceID) == 0 {
+					continue
+				}
+				info := logServiceInfo{ID: logServiceID[0], OdataID: logService}
+				logState, _, _ := s.getDeviceData(deviceIPAddress, logService, authStr, 1, "ServiceEnabled")
+				if len(logState) != 0 {
+					info.ServiceEnabled, _ = strconv.ParseBool(logState[0])
+				}
+				services = append(services, info)
+			}
+		}
+	}
+	if len(services) == 0 {
+		logrus.Errorf(ErrGetLogServiceRfAPI.String())
+		return nil, http.StatusNotFound, errors.New(ErrGetLogServiceRfAPI.String())
+	}
+	return services, http.StatusOK, nil
+}
+
+/* splitLogServiceScope() returns the collections searched for the log service id and the id itself, a "manager/"
+ * or "system/" prefix restricts the search to the BMC or the host logs (e.g. "system/SEL"), a bare "manager" or
+ * "system" selects the first log service of the collection
+ */
+func splitLogServiceScope(id string) (collections []string, logServiceID string) {
+	scopes := map[string]string{"manager": RfManager, "system": RfSystems}
+	scope := strings.SplitN(id, "/", 2)
+	if collection, ok := scopes[strings.ToLower(scope[0])]; ok {
+		if len(scope) == 2 {
+			logServiceID = scope[1]
+		}
+		return []string{collection}, logServiceID
+	}
+	return []string{RfManager, RfSystems}, id
+}
+
 func (s *Server) checkLogServiceState(deviceIPAddress, authStr, id string) (logService string, state bool) {
 	state = false
-	managerMembers, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
-	for _, managerMember := range managerMembers {
-		logServices, _, _ := s.getDeviceData(deviceIPAddress, managerMember+"/LogServices", authStr, 2, "@odata.id")
-		for _, logService = range logServices {
-			logserviceID, _, _ := s.getDeviceData(deviceIPAddress, logService, authStr, 1, "Id")
-			if logserviceID[0] == id {
-				logState, _, _ := s.getDeviceData(deviceIPAddress, logService, authStr, 1, "ServiceEnabled")
-				if logState == nil {
-					logrus.Errorf(ErrGetLogServiceStateFailed.String())
-					return "", false
+	collections, id := splitLogServiceScope(id)
+	for _, collection := range collections {
+		members, _, _ := s.getDeviceData(deviceIPAddress, collection, authStr, 2, "@odata.id")
+		for _, member := range members {
+			logServices, _, _ := s.getDeviceData(deviceIPAddress, member+"/LogServices", authStr, 2, "@odata.id")
+			for _, logService = range logServices {
+				logserviceID, _, _ := s.getDeviceData(deviceIPAddress, logService, authStr, 1, "Id")
+				if len(logserviceID) != 0 && (id == "" || logserviceID[0] == id) {
+					logState, _, _ := s.getDeviceData(deviceIPAddress, logService, authStr, 1, "ServiceEnabled")
+					if logState == nil {
+						logrus.Errorf(ErrGetLogServiceStateFailed.String())
+						return "", false
+					}
+					state, _ = strconv.ParseBool(logState[0])
+					return logService, state
 				}
-				state, _ = strconv.ParseBool(logState[0])
-				return logService, state
 			}
 		}
 	}
@@ -102,7 +159,20 @@ func (s *Server) resetDeviceLogData(deviceIPAddress, authStr, id string) (status
 	return statusCode, nil
 }
 
+func (s *Server) getDefaultLogServiceID(deviceIPAddress, authStr string) (id string, statusCode int, err error) {
+	services, statusCode, err := s.listDeviceLogServices(deviceIPAddress, authStr)
+	if err != nil {
+		return "", statusCode, err
+	}
+	return services[0].ID, statusCode, nil
+}
+
 func (s *Server) getDeviceLogData(deviceIPAddress, authStr, id string) (retData []string, statusCode int, err error) {
+	if id == "" {
+		if id, statusCode, err = s.getDefaultLogServiceID(deviceIPAddress, authStr); err != nil {
+			return nil, statusCode, err
+		}
+	}
 	logServiceLoc, _ := s.checkLogServiceState(deviceIPAddress, authStr, id)
 	if logServiceLoc == "" {
 		logrus.Errorf(ErrGetLogServiceRfAPI.String())
@@ -127,3 +197,208 @@ func (s *Server) getDeviceLogData(deviceIPAddress, authStr, id string) (retData
 	dataSlice = append(dataSlice, string(jsonData))
 	return dataSlice, statusCode, nil
 }
+
+// logStreamBatchSize is the number of log entries sent in one message of StreamDeviceLogData
+const logStreamBatchSize = 100
+
+/* streamDeviceLogData() reads the log entries of the log service page by page, following Members@odata.nextLink,
+ * and hands them to send in batches of logStreamBatchSize so a large log is never held in memory at once
+ */
+func (s *Server) streamDeviceLogData(deviceIPAddress, authStr, id string, send func(entries []string) error) (statusCode int, err error) {
+	if id == "" {
+		if id, statusCode, err = s.getDefaultLogServiceID(deviceIPAddress, authStr); err != nil {
+			return statusCode, err
+		}
+	}
+	logServiceLoc, _ := s.checkLogServiceState(deviceIPAddress, authStr, id)
+	if logServiceLoc == "" {
+		logrus.Errorf(ErrGetLogServiceRfAPI.String())
+		return http.StatusBadRequest, errors.New(ErrGetLogServiceRfAPI.String())
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	batch := []string{}
+	for page := logServiceLoc + "/Entries"; page != ""; {
+		httpData, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, page, userAuthData)
+		if statusCode != http.StatusOK || httpData == nil {
+			logrus.Errorf(ErrGetDeviceData.String(strconv.Itoa(statusCode)))
+			return statusCode, errors.New(ErrGetDeviceData.String(strconv.Itoa(statusCode)))
+		}
+		members, _ := httpData["Members"].([]interface{})
+		for _, member := range members {
+			jsonData, err := json.Marshal(member)
+			if err != nil {
+				return http.StatusInternalServerError, errors.New(ErrHTTPDataUpdateFailed.String())
+			}
+			batch = append(batch, string(jsonData))
+			if len(batch) == logStreamBatchSize {
+				if err := send(batch); err != nil {
+					return http.StatusInternalServerError, err
+				}
+				batch = []string{}
+			}
+		}
+		page, _ = httpData["Members@odata.nextLink"].(string)
+	}
+	if len(batch) != 0 {
+		if err := send(batch); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// logCursorIdleTimeout is the time after which the cursor of a log stream that stopped polling is forgotten
+const logCursorIdleTimeout = time.Hour
+
+// logCursor is the newest log entry a stream has seen, tracked by Created time or by numeric Id for entries without one
+type logCursor struct {
+	Started       bool
+	Created       time.Time
+	SeenAtCreated map[string]bool
+	ID            int64
+	UsedAt        time.Time
+}
+
+/* isNew() reports whether the log entry is newer than the cursor. Entries are compared by their Created time, the
+ * entries sharing the newest Created time are told apart by @odata.id. Entries without Created are compared by
+ * numeric Id, an entry that has neither is only returned by the first call of a stream
+ */
+func (cursor *logCursor) isNew(entry map[string]interface{}) bool {
+	if !cursor.Started {
+		return true
+	}
+	odataID, _ := entry["@odata.id"].(string)
+	if created, ok := logEntryCreated(entry); ok {
+		return created.After(cursor.Created) || (created.Equal(cursor.Created) && !cursor.SeenAtCreated[odataID])
+	}
+	if id, ok := logEntryID(entry); ok {
+		return id > cursor.ID
+	}
+	return false
+}
+
+// advance moves the cursor past the log entry
+func (cursor *logCursor) advance(entry map[string]interface{}) {
+	odataID, _ := entry["@odata.id"].(string)
+	if created, ok := logEntryCreated(entry); ok {
+		if created.After(cursor.Created) {
+			cursor.Created = created
+			cursor.SeenAtCreated = make(map[string]bool)
+		}
+		if created.Equal(cursor.Created) {
+			cursor.SeenAtCreated[odataID] = true
+		}
+	} else if id, ok := logEntryID(entry); ok && id > cursor.ID {
+		cursor.ID = id
+	}
+}
+
+func logEntryCreated(entry map[string]interface{}) (time.Time, bool) {
+	value, _ := entry["Created"].(string)
+	created, err := time.Parse(time.RFC3339, value)
+	return created, err == nil
+}
+
+func logEntryID(entry map[string]interface{}) (int64, bool) {
+	value, _ := entry["Id"].(string)
+	id, err := strconv.ParseInt(value, 10, 64)
+	return id, err == nil
+}
+
+/* getNewDeviceLogData() returns the log entries added since the previous call of the same stream for the device and
+ * log service. The server keeps a cursor per stream holding the newest Created time (or numeric Id) it returned, so
+ * the order of Members does not matter and concurrent streams do not take entries from each other. A stream that
+ * does not poll for logCursorIdleTimeout starts over.
+ */
+func (s *Server) getNewDeviceLogData(deviceIPAddress, authStr, id, streamID string) (retData []string, statusCode int, err error) {
+	if id == "" {
+		if id, statusCode, err = s.getDefaultLogServiceID(deviceIPAddress, authStr); err != nil {
+			return nil, statusCode, err
+		}
+	}
+	logServiceLoc, _ := s.checkLogServiceState(deviceIPAddress, authStr, id)
+	if logServiceLoc == "" {
+		logrus.Errorf(ErrGetLogServiceRfAPI.String())
+		return nil, http.StatusBadRequest, errors.New(ErrGetLogServiceRfAPI.String())
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	members, statusCode, err := getLogEntries(logServiceLoc+"/Entries", func(page string) (map[string]interface{}, int, error) {
+		return getHTTPBodyDataByRfAPI(deviceIPAddress, page, userAuthData)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	dataSlice, err := s.advanceLogCursor(deviceIPAddress, id+"/"+streamID, members)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return dataSlice, http.StatusOK, nil
+}
+
+/* getLogEntries() returns the Members of the log entry collection at entriesLoc and of every page following it by
+ * Members@odata.nextLink, fetch reads one page from the device
+ */
+func getLogEntries(entriesLoc string, fetch func(page string) (map[string]interface{}, int, error)) (members []interface{}, statusCode int, err error) {
+	for page := entriesLoc; page != ""; {
+		httpData, statusCode, _ := fetch(page)
+		if statusCode != http.StatusOK || httpData == nil {
+			logrus.Errorf(ErrGetDeviceData.String(strconv.Itoa(statusCode)))
+			return nil, statusCode, errors.New(ErrGetDeviceData.String(strconv.Itoa(statusCode)))
+		}
+		pageMembers, _ := httpData["Members"].([]interface{})
+		members = append(members, pageMembers...)
+		page, _ = httpData["Members@odata.nextLink"].(string)
+	}
+	return members, http.StatusOK, nil
+}
+
+/* advanceLogCursor() returns the members newer than the log cursor of the stream and moves the cursor past them,
+ * cursors idle for logCursorIdleTimeout are dropped
+ */
+func (s *Server) advanceLogCursor(deviceIPAddress, stream string, members []interface{}) (dataSlice []string, err error) {
+	dev := s.devicemap[deviceIPAddress]
+	dev.LogCursorLock.Lock()
+	defer dev.LogCursorLock.Unlock()
+	if dev.LogCursors == nil {
+		dev.LogCursors = make(map[string]*logCursor)
+	}
+	now := time.Now()
+	for key, cursor := range dev.LogCursors {
+		if now.Sub(cursor.UsedAt) > logCursorIdleTimeout {
+			delete(dev.LogCursors, key)
+		}
+	}
+	cursor := dev.LogCursors[stream]
+	if cursor == nil {
+		cursor = &logCursor{}
+		dev.LogCursors[stream] = cursor
+	}
+	cursor.UsedAt = now
+	newEntries := []map[string]interface{}{}
+	for _, member := range members {
+		if entry, ok := member.(map[string]interface{}); ok && cursor.isNew(entry) {
+			newEntries = append(newEntries, entry)
+		}
+	}
+	dataSlice = []string{}
+	for _, entry := range newEntries {
+		jsonData, err := json.Marshal(entry)
+		if err != nil {
+			return nil, errors.New(ErrHTTPDataUpdateFailed.String())
+		}
+		dataSlice = append(dataSlice, string(jsonData))
+	}
+	for _, entry := range newEntries {
+		cursor.advance(entry)
+	}
+	cursor.Started = true
+	return dataSlice, nil
+}
@@ -0,0 +1,137 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func logMembers(t *testing.T, data string) (members []interface{}) {
+	if err := json.Unmarshal([]byte(data), &members); err != nil {
+		t.Fatal(err)
+	}
+	return members
+}
+
+func logEntryIDs(t *testing.T, entries []string) (ids []string) {
+	for _, entry := range entries {
+		data := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(entry), &data); err != nil {
+			t.Fatal(err)
+		}
+		id, _ := data["Id"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestAdvanceLogCursor(t *testing.T) {
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}}
+	first := logMembers(t, `[
+		{"@odata.id": "/e/2", "Id": "2", "Created": "2021-06-01T10:00:02Z"},
+		{"@odata.id": "/e/1", "Id": "1", "Created": "2021-06-01T10:00:01Z"}]`)
+	second := logMembers(t, `[
+		{"@odata.id": "/e/3", "Id": "3", "Created": "2021-06-01T10:00:03Z"},
+		{"@odata.id": "/e/4", "Id": "4", "Created": "2021-06-01T10:00:02Z"},
+		{"@odata.id": "/e/2", "Id": "2", "Created": "2021-06-01T10:00:02Z"},
+		{"@odata.id": "/e/1", "Id": "1", "Created": "2021-06-01T10:00:01Z"}]`)
+	tests := []struct {
+		name    string
+		stream  string
+		members []interface{}
+		want    []string
+	}{
+		{"first call returns every entry, newest first", "Log/a", first, []string{"2", "1"}},
+		{"nothing new", "Log/a", first, nil},
+		{"new entries in any order, same Created told apart", "Log/a", second, []string{"3", "4"}},
+		{"another stream has its own cursor", "Log/b", second, []string{"3", "4", "2", "1"}},
+		{"first stream is not moved by the second", "Log/a", second, nil},
+	}
+	for _, tt := range tests {
+		got, err := s.advanceLogCursor("192.168.4.27:8888", tt.stream, tt.members)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		ids := logEntryIDs(t, got)
+		if len(ids) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, ids, tt.want)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, ids, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestLogCursorWithoutCreated(t *testing.T) {
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}}
+	s.advanceLogCursor("192.168.4.27:8888", "Log/a", logMembers(t, `[{"Id": "9"}, {"Id": "10"}]`))
+	got, _ := s.advanceLogCursor("192.168.4.27:8888", "Log/a", logMembers(t, `[{"Id": "11"}, {"Id": "9"}, {"Id": "10"}]`))
+	if ids := logEntryIDs(t, got); len(ids) != 1 || ids[0] != "11" {
+		t.Errorf("got %v, want [11]", ids)
+	}
+}
+
+func TestGetLogEntriesFollowsNextLink(t *testing.T) {
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}}
+	pages := map[string]string{
+		"/Log/Entries": `{"Members": [
+			{"@odata.id": "/e/4", "Id": "4", "Created": "2021-06-01T10:00:04Z"},
+			{"@odata.id": "/e/3", "Id": "3", "Created": "2021-06-01T10:00:03Z"}],
+			"Members@odata.nextLink": "/Log/Entries?$skip=2"}`,
+		"/Log/Entries?$skip=2": `{"Members": [
+			{"@odata.id": "/e/2", "Id": "2", "Created": "2021-06-01T10:00:02Z"},
+			{"@odata.id": "/e/1", "Id": "1", "Created": "2021-06-01T10:00:01Z"}]}`,
+	}
+	fetch := func(page string) (map[string]interface{}, int, error) {
+		data, ok := pages[page]
+		if !ok {
+			return nil, http.StatusNotFound, nil
+		}
+		httpData := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(data), &httpData); err != nil {
+			t.Fatal(err)
+		}
+		return httpData, http.StatusOK, nil
+	}
+	members, statusCode, err := getLogEntries("/Log/Entries", fetch)
+	if err != nil || statusCode != http.StatusOK {
+		t.Fatalf("getLogEntries: %d %v", statusCode, err)
+	}
+	got, _ := s.advanceLogCursor("192.168.4.27:8888", "Log/a", members)
+	if ids := logEntryIDs(t, got); strings.Join(ids, ",") != "4,3,2,1" {
+		t.Errorf("got %v, want the entries of both pages [4 3 2 1]", ids)
+	}
+	pages["/Log/Entries?$skip=2"] = `{"Members": [
+		{"@odata.id": "/e/2", "Id": "2", "Created": "2021-06-01T10:00:02Z"},
+		{"@odata.id": "/e/1", "Id": "1", "Created": "2021-06-01T10:00:01Z"}],
+		"Members@odata.nextLink": "/Log/Entries?$skip=4"}`
+	if _, statusCode, err := getLogEntries("/Log/Entries", fetch); err == nil || statusCode != http.StatusNotFound {
+		t.Errorf("missing page: got %d %v, want %d and an error", statusCode, err, http.StatusNotFound)
+	}
+}
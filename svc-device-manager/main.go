@@ -44,7 +44,7 @@ var (
 //NewGrpcServer ...
 func NewGrpcServer(grpcport string) (l net.Listener, g *grpc.Server, e error) {
 	logrus.Infof("Listening %s\n", grpcport)
-	g = grpc.NewServer()
+	g = grpc.NewServer(grpc.UnaryInterceptor(schemaVersionUnaryInterceptor), grpc.StreamInterceptor(schemaVersionStreamInterceptor))
 	l, e = net.Listen("tcp", grpcport)
 	return
 }
@@ -57,6 +57,9 @@ func (s *Server) startGrpcServer() {
 	}
 	s.gRPCserver = gserver
 	manager.RegisterDeviceManagementServer(gserver, s)
+	if GlobalConfig.MetricsAddress != "" {
+		go s.startMetricsServer()
+	}
 	if err := gserver.Serve(listener); err != nil {
 		logrus.Errorf("Failed to run gRPC server: %s ", err)
 		panic(err)
@@ -124,11 +127,24 @@ func (s *Server) validateIPAddress(ipAddress string, detectDevice bool) (msg str
 	return
 }
 
-func init() {
+/* newLogFormatter() returns the logrus formatter of GlobalConfig.LogFormat, "json" writes each entry as a JSON
+ * object for log pipelines and "text" keeps the full timestamp text lines
+ */
+func newLogFormatter(format string) logrus.Formatter {
+	if format == "json" {
+		return &logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano}
+	}
+	if format != "text" {
+		logrus.Warnf("Unknown log format %s, text is used", format)
+	}
 	Formatter := new(logrus.TextFormatter)
 	Formatter.TimestampFormat = "02-01-2006 15:04:05.000000"
 	Formatter.FullTimestamp = true
-	logrus.SetFormatter(Formatter)
+	return Formatter
+}
+
+func init() {
+	logrus.SetFormatter(newLogFormatter(GlobalConfig.LogFormat))
 	logrus.SetLevel(logrus.DebugLevel)
 }
 
@@ -137,7 +153,9 @@ func main() {
 	if os.Geteuid() == 0 {
 		logrus.Fatal("Device Manager should not run with root privileges")
 	}
-	logrus.Info("Starting Device Manager")
+	logrus.Infof("Starting Device Manager %s (commit %s, built %s)", version, gitCommit, buildDate)
+	ProcessGlobalOptions()
+	loadDeviceTransports()
 
 	if conf, err := config.LoadConfiguration(); err != nil {
 		logrus.Fatal("error while loading config: ", err)
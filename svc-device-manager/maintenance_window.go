@@ -0,0 +1,81 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	logrus "github.com/sirupsen/logrus"
+)
+
+//MaintenanceHeader is the Kafka header set to "true" on the messages of a device published during its maintenance window
+const MaintenanceHeader = "maintenance"
+
+/* setMaintenanceWindow() sets the window, in Unix seconds, during which the messages of device are tagged as
+ * maintenance, a window of 0 to 0 clears it
+ */
+func (s *Server) setMaintenanceWindow(deviceIPAddress string, start, end int64) (statusCode int, err error) {
+	dev := s.devicemap[deviceIPAddress]
+	dev.MaintenanceLock.Lock()
+	defer dev.MaintenanceLock.Unlock()
+	if start == 0 && end == 0 {
+		dev.MaintenanceStart, dev.MaintenanceEnd = time.Time{}, time.Time{}
+		return http.StatusOK, nil
+	}
+	if end <= start || end <= time.Now().Unix() {
+		logrus.Errorf(ErrMaintenanceWindowInvalid.String(strconv.FormatInt(start, 10), strconv.FormatInt(end, 10)))
+		return http.StatusBadRequest, errors.New(ErrMaintenanceWindowInvalid.String(strconv.FormatInt(start, 10), strconv.FormatInt(end, 10)))
+	}
+	dev.MaintenanceStart, dev.MaintenanceEnd = time.Unix(start, 0), time.Unix(end, 0)
+	return http.StatusOK, nil
+}
+
+//inMaintenance reports whether the maintenance window of device is open
+func (s *Server) inMaintenance(deviceIPAddress string) bool {
+	dev := s.devicemap[deviceIPAddress]
+	dev.MaintenanceLock.Lock()
+	defer dev.MaintenanceLock.Unlock()
+	now := time.Now()
+	return !dev.MaintenanceStart.IsZero() && !now.Before(dev.MaintenanceStart) && now.Before(dev.MaintenanceEnd)
+}
+
+/* publishDeviceMessage() sends data to the Kafka topic of device. Every message carries the schema version in the
+ * SchemaVersionKey header, as the polled Redfish documents are published unchanged, and during the maintenance window
+ * of device the "maintenance: true" header so consumers can filter the expected events
+ */
+func (s *Server) publishDeviceMessage(deviceIPAddress string, data []byte) {
+	if !strings.Contains(deviceIPAddress, ":") {
+		return
+	}
+	ipAddr := strings.Replace(deviceIPAddress, ":", "-", 1)
+	msg := &sarama.ProducerMessage{Topic: managerTopic + "-" + ipAddr, Value: sarama.StringEncoder(data)}
+	msg.Headers = []sarama.RecordHeader{{Key: []byte(SchemaVersionKey), Value: []byte(strconv.Itoa(SchemaVersion))}}
+	if s.inMaintenance(deviceIPAddress) {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(MaintenanceHeader), Value: []byte("true")})
+	}
+	s.dataproducer.Input() <- msg
+}
@@ -0,0 +1,72 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+)
+
+func TestPublishDeviceMessageHeaders(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, config)
+	defer producer.Close()
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}, dataproducer: producer}
+	tests := []struct {
+		name        string
+		maintenance bool
+		want        map[string]string
+	}{
+		{"outside maintenance window", false, map[string]string{SchemaVersionKey: strconv.Itoa(SchemaVersion)}},
+		{"in maintenance window", true, map[string]string{SchemaVersionKey: strconv.Itoa(SchemaVersion), MaintenanceHeader: "true"}},
+	}
+	for _, tt := range tests {
+		dev := s.devicemap["192.168.4.27:8888"]
+		dev.MaintenanceStart, dev.MaintenanceEnd = time.Time{}, time.Time{}
+		if tt.maintenance {
+			dev.MaintenanceStart, dev.MaintenanceEnd = time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+		}
+		producer.ExpectInputAndSucceed()
+		s.publishDeviceMessage("192.168.4.27:8888", []byte(`{"Id":"1"}`))
+		msg := <-producer.Successes()
+		if msg.Topic != "manager-192.168.4.27-8888" {
+			t.Errorf("%s: topic = %s", tt.name, msg.Topic)
+		}
+		headers := map[string]string{}
+		for _, header := range msg.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		if len(headers) != len(tt.want) {
+			t.Errorf("%s: headers = %v, want %v", tt.name, headers, tt.want)
+		}
+		for key, value := range tt.want {
+			if headers[key] != value {
+				t.Errorf("%s: header %s = %q, want %q", tt.name, key, headers[key], value)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//manufactureDateKeys are the OEM property names vendors use for the manufacture date
+var manufactureDateKeys = []string{"manufacturedate", "manufacturingdate", "productiondate", "mfgdate"}
+
+//manufacturingInfo holds the FRU identity, manufacture date and OEM warranty fields of a device
+type manufacturingInfo struct {
+	Manufacturer    string
+	Model           string
+	SerialNumber    string
+	PartNumber      string
+	SKU             string
+	ManufactureDate string
+	Warranty        map[string]string
+}
+
+/* findOemFields() returns the string or number values of the OEM data whose property name contains one of the
+ * lowercase keys, each keyed by its dotted path below Oem
+ */
+func findOemFields(data interface{}, path string, keys []string, fields map[string]string) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for name, member := range value {
+			memberPath := name
+			if path != "" {
+				memberPath = path + "." + name
+			}
+			switch member.(type) {
+			case string, float64, bool:
+				for _, key := range keys {
+					if strings.Contains(strings.ToLower(name), key) {
+						fields[memberPath] = fmt.Sprint(member)
+						break
+					}
+				}
+			default:
+				findOemFields(member, memberPath, keys, fields)
+			}
+		}
+	case []interface{}:
+		for i, element := range value {
+			findOemFields(element, fmt.Sprintf("%s[%d]", path, i), keys, fields)
+		}
+	}
+}
+
+/* getManufacturingInfo() reads the identity of the first chassis, or of the first system for properties the chassis lacks,
+ * the manufacture date is the ProductionDate of the chassis assembly or an OEM date, fields the device lacks stay empty
+ */
+func (s *Server) getManufacturingInfo(deviceIPAddress, authStr string) (info manufacturingInfo, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return info, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	resources := []map[string]interface{}{}
+	if chassisOdataIds := getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData); len(chassisOdataIds) != 0 {
+		chassisData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, chassisOdataIds[0], userAuthData)
+		if chassisData != nil {
+			resources = append(resources, chassisData)
+		}
+	}
+	if systemOdataIds := getMemberOdataIds(deviceIPAddress, RfSystems, userAuthData); len(systemOdataIds) != 0 {
+		systemData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, systemOdataIds[0], userAuthData)
+		if systemData != nil {
+			resources = append(resources, systemData)
+		}
+	}
+	if len(resources) == 0 {
+		logrus.Errorf(ErrManufacturingInfoNotSupported.String())
+		return info, http.StatusNotImplemented, errors.New(ErrManufacturingInfoNotSupported.String())
+	}
+	info.Warranty = map[string]string{}
+	dates := map[string]string{}
+	for _, resource := range resources {
+		for property, value := range map[string]*string{"Manufacturer": &info.Manufacturer, "Model": &info.Model,
+			"SerialNumber": &info.SerialNumber, "PartNumber": &info.PartNumber, "SKU": &info.SKU} {
+			if *value == "" {
+				*value, _ = resource[property].(string)
+			}
+		}
+		findOemFields(resource["Oem"], "", []string{"warranty"}, info.Warranty)
+		findOemFields(resource["Oem"], "", manufactureDateKeys, dates)
+	}
+	if assemblyURI := getOdataID(resources[0], "Assembly"); assemblyURI != "" {
+		assemblyData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, assemblyURI, userAuthData)
+		assemblies, _ := assemblyData["Assemblies"].([]interface{})
+		for _, assembly := range assemblies {
+			assemblyInfo, _ := assembly.(map[string]interface{})
+			if productionDate, _ := assemblyInfo["ProductionDate"].(string); productionDate != "" {
+				info.ManufactureDate = productionDate
+				break
+			}
+		}
+	}
+	if info.ManufactureDate == "" && len(dates) != 0 {
+		paths := []string{}
+		for path := range dates {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		info.ManufactureDate = dates[paths[0]]
+	}
+	return info, http.StatusOK, nil
+}
@@ -0,0 +1,108 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//portStats holds the traffic counters of one network port
+type portStats struct {
+	OdataID  string
+	RXBytes  uint64
+	TXBytes  uint64
+	RXErrors uint64
+	TXErrors uint64
+}
+
+func getMemberOdataIds(deviceIPAddress, collectionURI string, userAuthData userAuth) (odataIds []string) {
+	collection, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, collectionURI, userAuthData)
+	members, _ := collection["Members"].([]interface{})
+	for _, member := range members {
+		if odataID, _ := member.(map[string]interface{})["@odata.id"].(string); odataID != "" {
+			odataIds = append(odataIds, odataID)
+		}
+	}
+	return odataIds
+}
+
+func getMetricCounter(metrics map[string]interface{}, key string) uint64 {
+	if value, ok := metrics[key].(float64); ok {
+		return uint64(value)
+	}
+	networking, _ := metrics["Networking"].(map[string]interface{})
+	value, _ := networking[key].(float64)
+	return uint64(value)
+}
+
+/* getNetworkPortStats() reads the PortMetrics of every port of every NetworkAdapter of the device chassis
+ */
+func (s *Server) getNetworkPortStats(deviceIPAddress, authStr string) (stats []portStats, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, chassisOdataID := range getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData) {
+		chassisData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, chassisOdataID, userAuthData)
+		adaptersURI := getOdataID(chassisData, "NetworkAdapters")
+		if adaptersURI == "" {
+			continue
+		}
+		for _, adapterOdataID := range getMemberOdataIds(deviceIPAddress, adaptersURI, userAuthData) {
+			adapterData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, adapterOdataID, userAuthData)
+			portsURI := getOdataID(adapterData, "Ports")
+			if portsURI == "" {
+				portsURI = getOdataID(adapterData, "NetworkPorts")
+			}
+			if portsURI == "" {
+				continue
+			}
+			for _, portOdataID := range getMemberOdataIds(deviceIPAddress, portsURI, userAuthData) {
+				portData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, portOdataID, userAuthData)
+				metricsURI := getOdataID(portData, "Metrics")
+				if metricsURI == "" {
+					continue
+				}
+				metrics, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, metricsURI, userAuthData)
+				if metrics == nil {
+					continue
+				}
+				stats = append(stats, portStats{
+					OdataID:  portOdataID,
+					RXBytes:  getMetricCounter(metrics, "RXBytes"),
+					TXBytes:  getMetricCounter(metrics, "TXBytes"),
+					RXErrors: getMetricCounter(metrics, "RXErrors"),
+					TXErrors: getMetricCounter(metrics, "TXErrors"),
+				})
+			}
+		}
+	}
+	if len(stats) == 0 {
+		logrus.Errorf(ErrPortStatsNotSupported.String())
+		return nil, http.StatusNotImplemented, errors.New(ErrPortStatsNotSupported.String())
+	}
+	return stats, http.StatusOK, nil
+}
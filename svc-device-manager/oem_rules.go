@@ -0,0 +1,108 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+
+	logrus "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+//OemFieldsKey is the member added to device data holding the OEM fields named by the OEM rules
+const OemFieldsKey = "OemFields"
+
+var (
+	//oemRules maps a vendor key of Oem to the field names and the JSON paths below Oem.<vendor> of their values
+	oemRules     map[string]map[string]string
+	oemRulesOnce sync.Once
+)
+
+/* loadOemRules() reads the OEM rules file of GlobalConfig.OemRules, e.g.
+ *   Dell:
+ *     BoardTemperature: $.DellSystem.BoardTemperatureCelsius
+ * an invalid rule is logged and skipped
+ */
+func loadOemRules() {
+	if GlobalConfig.OemRules == "" {
+		return
+	}
+	rulesFile, err := ioutil.ReadFile(GlobalConfig.OemRules)
+	if err == nil {
+		err = yaml.Unmarshal(rulesFile, &oemRules)
+	}
+	if err != nil {
+		logrus.Errorf(ErrOemRulesInvalid.String(GlobalConfig.OemRules, err.Error()))
+		oemRules = nil
+		return
+	}
+	for vendor, fields := range oemRules {
+		for name, path := range fields {
+			if _, err := parseJSONPath(path); err != nil {
+				logrus.Errorf(ErrOemRulesInvalid.String(GlobalConfig.OemRules, vendor+"."+name+": "+err.Error()))
+				delete(fields, name)
+			}
+		}
+	}
+}
+
+/* applyOemRules() adds the OemFields member to every device data document whose Oem has a vendor with rules, it
+ * holds the value matched by each rule, a rule matching nothing is omitted
+ */
+func applyOemRules(deviceData []string) []string {
+	oemRulesOnce.Do(loadOemRules)
+	if len(oemRules) == 0 {
+		return deviceData
+	}
+	for index, document := range deviceData {
+		data := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(document), &data); err != nil {
+			continue
+		}
+		oem, _ := data["Oem"].(map[string]interface{})
+		oemFields := map[string]interface{}{}
+		for vendor, fields := range oemRules {
+			vendorData, ok := oem[vendor]
+			if !ok {
+				continue
+			}
+			for name, path := range fields {
+				steps, _ := parseJSONPath(path)
+				if matches := matchJSONPath(vendorData, steps); len(matches) == 1 {
+					oemFields[name] = matches[0]
+				} else if len(matches) > 1 {
+					oemFields[name] = matches
+				}
+			}
+		}
+		if len(oemFields) == 0 {
+			continue
+		}
+		data[OemFieldsKey] = oemFields
+		if documentData, err := json.Marshal(data); err == nil {
+			deviceData[index] = string(documentData)
+		}
+	}
+	return deviceData
+}
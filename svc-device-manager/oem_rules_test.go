@@ -0,0 +1,55 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApplyOemRules(t *testing.T) {
+	oemRulesOnce.Do(func() {})
+	oemRules = map[string]map[string]string{
+		"Dell": {"BoardTemperature": "$.DellSystem.BoardTemperatureCelsius", "Missing": "$.DellSystem.Missing"},
+		"Hpe":  {"PostState": "$.PostState"},
+	}
+	defer func() { oemRules = nil }()
+	deviceData := applyOemRules([]string{
+		`{"Id":"1","Oem":{"Dell":{"DellSystem":{"BoardTemperatureCelsius":41}}}}`,
+		`{"Id":"2","Oem":{"Contoso":{"PostState":"FinishedPost"}}}`,
+		`not JSON`,
+	})
+	data := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(deviceData[0]), &data); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"BoardTemperature": 41.0}; !reflect.DeepEqual(data[OemFieldsKey], want) {
+		t.Errorf("OemFields = %v, want %v", data[OemFieldsKey], want)
+	}
+	if deviceData[1] != `{"Id":"2","Oem":{"Contoso":{"PostState":"FinishedPost"}}}` {
+		t.Errorf("document without a vendor with rules changed: %s", deviceData[1])
+	}
+	if deviceData[2] != `not JSON` {
+		t.Errorf("invalid document changed: %s", deviceData[2])
+	}
+}
@@ -0,0 +1,111 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//pcieDevice holds the inventory of one PCIeDevice resource
+type pcieDevice struct {
+	OdataID         string
+	Name            string
+	DeviceType      string
+	DeviceClass     string
+	Manufacturer    string
+	Model           string
+	FirmwareVersion string
+	State           string
+	Health          string
+}
+
+/* getPCIeDeviceOdataIds() returns the PCIeDevices linked by every chassis collection and by the PCIeDevices array
+ * of every system, a chassis or system without PCIe devices is skipped
+ */
+func getPCIeDeviceOdataIds(deviceIPAddress string, userAuthData userAuth) (odataIds []string) {
+	seen := map[string]bool{}
+	addOdataID := func(odataID string) {
+		if odataID != "" && !seen[odataID] {
+			seen[odataID] = true
+			odataIds = append(odataIds, odataID)
+		}
+	}
+	for _, chassisOdataID := range getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData) {
+		chassisData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, chassisOdataID, userAuthData)
+		if collectionURI := getOdataID(chassisData, "PCIeDevices"); collectionURI != "" {
+			for _, odataID := range getMemberOdataIds(deviceIPAddress, collectionURI, userAuthData) {
+				addOdataID(odataID)
+			}
+		}
+	}
+	for _, systemOdataID := range getMemberOdataIds(deviceIPAddress, RfSystems, userAuthData) {
+		systemData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, systemOdataID, userAuthData)
+		links, _ := systemData["PCIeDevices"].([]interface{})
+		for _, link := range links {
+			linkData, _ := link.(map[string]interface{})
+			odataID, _ := linkData["@odata.id"].(string)
+			addOdataID(odataID)
+		}
+	}
+	return odataIds
+}
+
+/* getPCIeDevices() reads name, type and firmware version of every PCIe device of device, the device class is
+ * taken from the first PCIeFunction (e.g. DisplayController for a GPU, NetworkController for a NIC)
+ */
+func (s *Server) getPCIeDevices(deviceIPAddress, authStr string) (devices []pcieDevice, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, odataID := range getPCIeDeviceOdataIds(deviceIPAddress, userAuthData) {
+		deviceData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, odataID, userAuthData)
+		if deviceData == nil {
+			continue
+		}
+		device := pcieDevice{OdataID: odataID}
+		device.Name, _ = deviceData["Name"].(string)
+		device.DeviceType, _ = deviceData["DeviceType"].(string)
+		device.Manufacturer, _ = deviceData["Manufacturer"].(string)
+		device.Model, _ = deviceData["Model"].(string)
+		device.FirmwareVersion, _ = deviceData["FirmwareVersion"].(string)
+		deviceStatus, _ := deviceData["Status"].(map[string]interface{})
+		device.State, _ = deviceStatus["State"].(string)
+		device.Health, _ = deviceStatus["Health"].(string)
+		if functionsURI := getOdataID(deviceData, "PCIeFunctions"); functionsURI != "" {
+			if functionOdataIds := getMemberOdataIds(deviceIPAddress, functionsURI, userAuthData); len(functionOdataIds) != 0 {
+				functionData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, functionOdataIds[0], userAuthData)
+				device.DeviceClass, _ = functionData["DeviceClass"].(string)
+			}
+		}
+		devices = append(devices, device)
+	}
+	if len(devices) == 0 {
+		logrus.Errorf(ErrPCIeDevicesNotSupported.String())
+		return nil, http.StatusNotImplemented, errors.New(ErrPCIeDevicesNotSupported.String())
+	}
+	return devices, http.StatusOK, nil
+}
@@ -0,0 +1,84 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//physicalLocation holds the Location of a chassis, the slot in its enclosure and the rack position
+type physicalLocation struct {
+	OdataID              string
+	Name                 string
+	ServiceLabel         string
+	LocationType         string
+	LocationOrdinalValue int64
+	Row                  string
+	Rack                 string
+	RackOffsetUnits      string
+	RackOffset           int64
+	ContainedBy          string
+}
+
+/* getPhysicalLocation() reads the Location object (PartLocation and Placement) of every chassis of device, a chassis
+ * without Location is skipped, ContainedBy names the enclosure of a blade or node
+ */
+func (s *Server) getPhysicalLocation(deviceIPAddress, authStr string) (locations []physicalLocation, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, chassisOdataID := range getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData) {
+		chassisData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, chassisOdataID, userAuthData)
+		locationData, ok := chassisData["Location"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		location := physicalLocation{OdataID: chassisOdataID}
+		location.Name, _ = chassisData["Name"].(string)
+		partLocation, _ := locationData["PartLocation"].(map[string]interface{})
+		location.ServiceLabel, _ = partLocation["ServiceLabel"].(string)
+		location.LocationType, _ = partLocation["LocationType"].(string)
+		if ordinal, ok := partLocation["LocationOrdinalValue"].(float64); ok {
+			location.LocationOrdinalValue = int64(ordinal)
+		}
+		placement, _ := locationData["Placement"].(map[string]interface{})
+		location.Row, _ = placement["Row"].(string)
+		location.Rack, _ = placement["Rack"].(string)
+		location.RackOffsetUnits, _ = placement["RackOffsetUnits"].(string)
+		if offset, ok := placement["RackOffset"].(float64); ok {
+			location.RackOffset = int64(offset)
+		}
+		links, _ := chassisData["Links"].(map[string]interface{})
+		location.ContainedBy = getOdataID(links, "ContainedBy")
+		locations = append(locations, location)
+	}
+	if len(locations) == 0 {
+		logrus.Errorf(ErrLocationNotSupported.String())
+		return nil, http.StatusNotImplemented, errors.New(ErrLocationNotSupported.String())
+	}
+	return locations, http.StatusOK, nil
+}
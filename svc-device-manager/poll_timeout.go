@@ -0,0 +1,72 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//MaxPollTimeout is the longest poll timeout in seconds a device can be given
+const MaxPollTimeout = 300
+
+//PollTimeouts holds the poll timeout in seconds of the devices which do not use GlobalConfig.PollTimeout
+var PollTimeouts = make(map[string]uint32)
+var pollTimeoutsLock sync.Mutex
+
+func setPollTimeout(deviceIPAddress string, seconds uint32) {
+	pollTimeoutsLock.Lock()
+	defer pollTimeoutsLock.Unlock()
+	if seconds == 0 {
+		delete(PollTimeouts, deviceIPAddress)
+		return
+	}
+	PollTimeouts[deviceIPAddress] = seconds
+}
+
+/* getPollTimeout() returns the time allowed to a Redfish GET of the polling cycle of device, 0 means no limit
+ */
+func getPollTimeout(deviceIPAddress string) time.Duration {
+	pollTimeoutsLock.Lock()
+	seconds, ok := PollTimeouts[deviceIPAddress]
+	pollTimeoutsLock.Unlock()
+	if !ok {
+		seconds = uint32(GlobalConfig.PollTimeout)
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func getTimeoutClient(deviceIPAddress string, timeout time.Duration) *http.Client {
+	client := getDeviceClient(deviceIPAddress)
+	client.Timeout = timeout
+	return client
+}
+
+func withRequestTimeout(request *http.Request, timeout time.Duration) (*http.Request, context.CancelFunc) {
+	if timeout == 0 {
+		return request, func() {}
+	}
+	ctx, cancel := context.WithTimeout(request.Context(), timeout)
+	return request.WithContext(ctx), cancel
+}
@@ -0,0 +1,129 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//powerCap holds the PowerLimit of the first PowerControl of a chassis Power resource
+type powerCap struct {
+	OdataID        string
+	LimitInWatts   float64
+	MinWatts       float64
+	MaxWatts       float64
+	Active         bool
+	CorrectionInMs uint64
+	LimitException string
+}
+
+/* parsePowerLimitRange() reads the "min:max" or "min:step:max" allowable range of LimitInWatts, the max falls back to
+ * the PowerCapacityWatts of the PowerControl
+ */
+func parsePowerLimitRange(control, limit map[string]interface{}) (minWatts, maxWatts float64) {
+	maxWatts, _ = control["PowerCapacityWatts"].(float64)
+	allowable, _ := limit["LimitInWatts@Redfish.AllowableNumbers"].([]interface{})
+	if len(allowable) == 0 {
+		return minWatts, maxWatts
+	}
+	bounds, _ := allowable[0].(string)
+	fields := strings.Split(bounds, ":")
+	if len(fields) < 2 {
+		return minWatts, maxWatts
+	}
+	if value, err := strconv.ParseFloat(fields[0], 64); err == nil {
+		minWatts = value
+	}
+	if value, err := strconv.ParseFloat(fields[len(fields)-1], 64); err == nil {
+		maxWatts = value
+	}
+	return minWatts, maxWatts
+}
+
+/* getPowerCap() reads the power limit of the first chassis exposing a PowerControl
+ */
+func (s *Server) getPowerCap(deviceIPAddress, authStr string) (powerLimit powerCap, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return powerLimit, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, chassisOdataID := range getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData) {
+		powerURI := chassisOdataID + "/Power"
+		powerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, powerURI, userAuthData)
+		powerControls, _ := powerData["PowerControl"].([]interface{})
+		if len(powerControls) == 0 {
+			continue
+		}
+		control, _ := powerControls[0].(map[string]interface{})
+		limit, ok := control["PowerLimit"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		powerLimit.OdataID = powerURI
+		powerLimit.LimitInWatts, _ = limit["LimitInWatts"].(float64)
+		powerLimit.Active = powerLimit.LimitInWatts > 0
+		correction, _ := limit["CorrectionInMs"].(float64)
+		powerLimit.CorrectionInMs = uint64(correction)
+		powerLimit.LimitException, _ = limit["LimitException"].(string)
+		powerLimit.MinWatts, powerLimit.MaxWatts = parsePowerLimitRange(control, limit)
+		return powerLimit, http.StatusOK, nil
+	}
+	logrus.Errorf(ErrPowerCapNotSupported.String())
+	return powerLimit, http.StatusNotImplemented, errors.New(ErrPowerCapNotSupported.String())
+}
+
+/* setPowerCap() patches the power limit after checking it against the advertised range, 0 watts removes the limit
+ */
+func (s *Server) setPowerCap(deviceIPAddress, authStr string, watts uint32) (statusCode int, err error) {
+	powerLimit, statusCode, err := s.getPowerCap(deviceIPAddress, authStr)
+	if err != nil {
+		return statusCode, err
+	}
+	limitInWatts := interface{}(nil)
+	if watts != 0 {
+		if float64(watts) < powerLimit.MinWatts || (powerLimit.MaxWatts > 0 && float64(watts) > powerLimit.MaxWatts) {
+			minWatts := strconv.FormatFloat(powerLimit.MinWatts, 'f', -1, 64)
+			maxWatts := strconv.FormatFloat(powerLimit.MaxWatts, 'f', -1, 64)
+			logrus.Errorf(ErrPowerCapOutOfRange.String(strconv.Itoa(int(watts)), minWatts, maxWatts))
+			return http.StatusBadRequest, errors.New(ErrPowerCapOutOfRange.String(strconv.Itoa(int(watts)), minWatts, maxWatts))
+		}
+		limitInWatts = watts
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	limitInfo := map[string]interface{}{
+		"PowerControl": []interface{}{
+			map[string]interface{}{"PowerLimit": map[string]interface{}{"LimitInWatts": limitInWatts}},
+		},
+	}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, powerLimit.OdataID, userAuthData, limitInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent && statusCode != http.StatusAccepted {
+		logrus.Errorf(ErrSetPowerCapFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrSetPowerCapFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
@@ -0,0 +1,91 @@
+/*Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+type powerSample struct {
+	Timestamp          time.Time
+	PowerConsumedWatts float64
+}
+
+/* samplePowerConsumption() sums PowerControl PowerConsumedWatts over the Power documents among the polled data,
+ * ok is false when none of them reports a reading
+ */
+func samplePowerConsumption(polledData []string) (watts float64, ok bool) {
+	for _, document := range polledData {
+		powerData := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(document), &powerData); err != nil {
+			continue
+		}
+		powerControls, _ := powerData["PowerControl"].([]interface{})
+		for _, powerControl := range powerControls {
+			control, _ := powerControl.(map[string]interface{})
+			if consumed, found := control["PowerConsumedWatts"].(float64); found {
+				watts += consumed
+				ok = true
+			}
+		}
+	}
+	return watts, ok
+}
+
+/* recordPowerSample() appends the power reading of the documents read in a polling cycle to the device history,
+ * keeping at most GlobalConfig.PowerHistoryDepth samples. No request is sent to the device, a cycle that did not
+ * read a Power resource adds no sample
+ */
+func (s *Server) recordPowerSample(deviceIPAddress string, polledData []string) {
+	watts, ok := samplePowerConsumption(polledData)
+	if !ok {
+		return
+	}
+	dev := s.devicemap[deviceIPAddress]
+	if dev == nil || GlobalConfig.PowerHistoryDepth <= 0 {
+		return
+	}
+	dev.PowerLock.Lock()
+	defer dev.PowerLock.Unlock()
+	dev.PowerHistory = append(dev.PowerHistory, powerSample{Timestamp: time.Now(), PowerConsumedWatts: watts})
+	if overflow := len(dev.PowerHistory) - GlobalConfig.PowerHistoryDepth; overflow > 0 {
+		dev.PowerHistory = dev.PowerHistory[overflow:]
+	}
+}
+
+func (s *Server) getPowerHistory(deviceIPAddress string) (history []powerSample, statusCode int, err error) {
+	dev := s.devicemap[deviceIPAddress]
+	if !dev.QueryState {
+		logrus.Errorf(ErrCollectingNotStarted.String())
+		return nil, http.StatusBadRequest, errors.New(ErrCollectingNotStarted.String())
+	}
+	dev.PowerLock.Lock()
+	defer dev.PowerLock.Unlock()
+	history = make([]powerSample, len(dev.PowerHistory))
+	copy(history, dev.PowerHistory)
+	return history, http.StatusOK, nil
+}
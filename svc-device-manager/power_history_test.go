@@ -0,0 +1,69 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"testing"
+)
+
+func TestSamplePowerConsumption(t *testing.T) {
+	tests := []struct {
+		name       string
+		polledData []string
+		wantWatts  float64
+		wantOK     bool
+	}{
+		{"no power resource polled", []string{`{"Id": "1", "ProcessorSummary": {"Count": 2}}`}, 0, false},
+		{"single chassis", []string{`{"PowerControl": [{"PowerConsumedWatts": 180.5}]}`}, 180.5, true},
+		{"summed over chassis and controls", []string{
+			`{"PowerControl": [{"PowerConsumedWatts": 100}, {"PowerConsumedWatts": 20}]}`,
+			`{"Id": "Thermal", "Temperatures": []}`,
+			`{"PowerControl": [{"PowerConsumedWatts": 30}, {"Name": "no reading"}]}`,
+		}, 150, true},
+		{"invalid document skipped", []string{`not json`, `{"PowerControl": [{"PowerConsumedWatts": 5}]}`}, 5, true},
+	}
+	for _, tt := range tests {
+		watts, ok := samplePowerConsumption(tt.polledData)
+		if watts != tt.wantWatts || ok != tt.wantOK {
+			t.Errorf("%s: samplePowerConsumption() = %v, %v, want %v, %v", tt.name, watts, ok, tt.wantWatts, tt.wantOK)
+		}
+	}
+}
+
+func TestRecordPowerSample(t *testing.T) {
+	depth := GlobalConfig.PowerHistoryDepth
+	defer func() { GlobalConfig.PowerHistoryDepth = depth }()
+	GlobalConfig.PowerHistoryDepth = 2
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}}
+	for _, data := range []string{
+		`{"PowerControl": [{"PowerConsumedWatts": 1}]}`,
+		`{"Temperatures": []}`,
+		`{"PowerControl": [{"PowerConsumedWatts": 2}]}`,
+		`{"PowerControl": [{"PowerConsumedWatts": 3}]}`,
+	} {
+		s.recordPowerSample("192.168.4.27:8888", []string{data})
+	}
+	history := s.devicemap["192.168.4.27:8888"].PowerHistory
+	if len(history) != 2 || history[0].PowerConsumedWatts != 2 || history[1].PowerConsumedWatts != 3 {
+		t.Errorf("PowerHistory = %v, want the samples 2 and 3", history)
+	}
+}
@@ -0,0 +1,76 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//powerSupplyInput holds the input readings of one PowerSupplies entry of a chassis Power resource
+type powerSupplyInput struct {
+	OdataID              string
+	Name                 string
+	LineInputVoltageType string
+	LineInputVoltage     float64
+	PowerInputWatts      float64
+	State                string
+	Health               string
+}
+
+/* getPowerRedundancy() reads the power Redundancy array and the power supply inputs of every chassis of device
+ */
+func (s *Server) getPowerRedundancy(deviceIPAddress, authStr string) (redundancies []redundancyGroup, supplies []powerSupplyInput, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, chassisOdataID := range getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData) {
+		powerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, chassisOdataID+"/Power", userAuthData)
+		redundancies = append(redundancies, parseRedundancyGroups(powerData)...)
+		powerSupplies, _ := powerData["PowerSupplies"].([]interface{})
+		for _, powerSupply := range powerSupplies {
+			supplyData, ok := powerSupply.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			supply := powerSupplyInput{}
+			supply.OdataID, _ = supplyData["@odata.id"].(string)
+			supply.Name, _ = supplyData["Name"].(string)
+			supply.LineInputVoltageType, _ = supplyData["LineInputVoltageType"].(string)
+			supply.LineInputVoltage, _ = supplyData["LineInputVoltage"].(float64)
+			supply.PowerInputWatts, _ = supplyData["PowerInputWatts"].(float64)
+			supplyStatus, _ := supplyData["Status"].(map[string]interface{})
+			supply.State, _ = supplyStatus["State"].(string)
+			supply.Health, _ = supplyStatus["Health"].(string)
+			supplies = append(supplies, supply)
+		}
+	}
+	if len(redundancies) == 0 && len(supplies) == 0 {
+		logrus.Errorf(ErrPowerRedundancyNotSupported.String())
+		return nil, nil, http.StatusNotImplemented, errors.New(ErrPowerRedundancyNotSupported.String())
+	}
+	return redundancies, supplies, http.StatusOK, nil
+}
@@ -0,0 +1,70 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+//requestLimiter is a token bucket pacing the Redfish requests sent to one device
+type requestLimiter struct {
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+var requestLimiters = make(map[string]*requestLimiter)
+var requestLimitersLock sync.Mutex
+
+/* waitRequestToken() blocks until the device bucket holds a token, polling and interactive commands share
+ * the bucket so the device sees at most GlobalConfig.MaxRequestsPerSecond requests per second
+ */
+func waitRequestToken(deviceIPAddress string) {
+	rate := float64(GlobalConfig.MaxRequestsPerSecond)
+	if rate <= 0 {
+		return
+	}
+	requestLimitersLock.Lock()
+	limiter := requestLimiters[deviceIPAddress]
+	if limiter == nil {
+		limiter = &requestLimiter{tokens: rate, last: time.Now()}
+		requestLimiters[deviceIPAddress] = limiter
+	}
+	requestLimitersLock.Unlock()
+
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	now := time.Now()
+	limiter.tokens += now.Sub(limiter.last).Seconds() * rate
+	if limiter.tokens > rate {
+		limiter.tokens = rate
+	}
+	limiter.last = now
+	if limiter.tokens < 1 {
+		delay := time.Duration((1 - limiter.tokens) / rate * float64(time.Second))
+		time.Sleep(delay)
+		limiter.tokens = 1
+		limiter.last = now.Add(delay)
+	}
+	limiter.tokens--
+}
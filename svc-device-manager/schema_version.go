@@ -0,0 +1,72 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	logrus "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	//SchemaVersion is the version of the JSON documents built by the device manager (temperature readings, certificate
+	//events) and of the Kafka messages of a device, bump it on breaking changes. Redfish documents relayed from the
+	//device are not versioned
+	SchemaVersion = 1
+	//SchemaVersionKey is the gRPC metadata key of the schema version requested by the client and served by the manager
+	SchemaVersionKey = "schema-version"
+)
+
+/* checkSchemaVersion() rejects a request asking for a schema version the manager cannot serve, a request without
+ * a version is served the current one, and returns the served version in the response header
+ */
+func checkSchemaVersion(ctx context.Context) error {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, requested := range md.Get(SchemaVersionKey) {
+			if version, err := strconv.Atoi(requested); err != nil || version != SchemaVersion {
+				logrus.Errorf(ErrSchemaVersionNotSupported.String(requested, strconv.Itoa(SchemaVersion)))
+				return status.Errorf(http.StatusBadRequest, ErrSchemaVersionNotSupported.String(requested, strconv.Itoa(SchemaVersion)))
+			}
+		}
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(SchemaVersionKey, strconv.Itoa(SchemaVersion)))
+	return nil
+}
+
+func schemaVersionUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := checkSchemaVersion(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func schemaVersionStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := checkSchemaVersion(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
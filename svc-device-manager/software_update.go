@@ -23,6 +23,7 @@ package main
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -42,9 +43,311 @@ const (
 	RfNOSUpdate = "/redfish/v1/UpdateService/FirmwareInventory/NOS"
 	//RfPackageUpdate
 	RfPackageUpdate = "/redfish/v1/UpdateService/SoftwareInventory/PACKAGE"
+	//RfFirmwareInventory ...
+	RfFirmwareInventory = "/redfish/v1/UpdateService/FirmwareInventory/"
+	//RfSoftwareInventory ...
+	RfSoftwareInventory = "/redfish/v1/UpdateService/SoftwareInventory/"
+	//RfUpdateService ...
+	RfUpdateService = "/redfish/v1/UpdateService/"
+	//RfTaskServiceTasks ...
+	RfTaskServiceTasks = "/redfish/v1/TaskService/Tasks/"
 )
 
-func (s *Server) sendDeviceSoftwareDownloadURI(deviceIPAddress, authStr, softwareType, URI string) (statusCode int, err error) {
+var (
+	//activeTaskStates ...
+	activeTaskStates = [...]string{"New", "Starting", "Running", "Pending", "Suspended", "Service", "Interrupted", "Stopping"}
+)
+
+/* findActiveUpdateTask() returns the @odata.id of the first active task whose payload targets the UpdateService,
+ * or whose name mentions an update when the device does not report the task payload
+ */
+func (s *Server) findActiveUpdateTask(deviceIPAddress, authStr string, userAuthData userAuth) (taskURI string) {
+	taskOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfTaskServiceTasks, authStr, 2, "@odata.id")
+	for _, taskOdataID := range taskOdataIds {
+		taskData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, taskOdataID, userAuthData)
+		if taskData == nil {
+			continue
+		}
+		taskState, _ := taskData["TaskState"].(string)
+		active := false
+		for _, state := range activeTaskStates {
+			if taskState == state {
+				active = true
+				break
+			}
+		}
+		if !active {
+			continue
+		}
+		if payload, ok := taskData["Payload"].(map[string]interface{}); ok {
+			if targetURI, _ := payload["TargetUri"].(string); strings.Contains(targetURI, "UpdateService") {
+				return taskOdataID
+			}
+			continue
+		}
+		if name, _ := taskData["Name"].(string); strings.Contains(strings.ToLower(name), "update") {
+			return taskOdataID
+		}
+	}
+	return ""
+}
+
+//updateServiceStatus holds what a software update needs from the UpdateService before it is started
+type updateServiceStatus struct {
+	ServiceEnabled    bool
+	Health            string
+	TransferProtocols []string
+	MultipartPush     bool
+	ApplyTimes        []string
+	ActiveTask        string
+	ActiveTaskState   string
+	ActiveTaskPercent uint32
+}
+
+/* getUpdateServiceStatus() reads whether the UpdateService is enabled, the transfer protocols of its SimpleUpdate
+ * action, whether it takes multipart pushes, its apply times and the active update task if any
+ */
+func (s *Server) getUpdateServiceStatus(deviceIPAddress, authStr string) (updateStatus updateServiceStatus, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return updateStatus, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	updateService, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfUpdateService, userAuthData)
+	if statusCode != http.StatusOK || updateService == nil {
+		logrus.Errorf(ErrGetUpdateServiceFailed.String(strconv.Itoa(statusCode)))
+		return updateStatus, statusCode, errors.New(ErrGetUpdateServiceFailed.String(strconv.Itoa(statusCode)))
+	}
+	updateStatus.ServiceEnabled, _ = updateService["ServiceEnabled"].(bool)
+	serviceStatus, _ := updateService["Status"].(map[string]interface{})
+	updateStatus.Health, _ = serviceStatus["Health"].(string)
+	actions, _ := updateService["Actions"].(map[string]interface{})
+	if simpleUpdate, ok := actions["#UpdateService.SimpleUpdate"].(map[string]interface{}); ok {
+		for _, parameter := range actionParameters(deviceIPAddress, userAuthData, simpleUpdate) {
+			if parameter.Name == "TransferProtocol" {
+				updateStatus.TransferProtocols = parameter.AllowableValues
+			}
+		}
+	}
+	pushURI, _ := updateService["MultipartHttpPushUri"].(string)
+	updateStatus.MultipartPush = pushURI != ""
+	applyTimeSupport, _ := updateService["@Redfish.OperationApplyTimeSupport"].(map[string]interface{})
+	supportedValues, _ := applyTimeSupport["SupportedValues"].([]interface{})
+	for _, value := range supportedValues {
+		if option, ok := value.(string); ok {
+			updateStatus.ApplyTimes = append(updateStatus.ApplyTimes, option)
+		}
+	}
+	if updateStatus.ActiveTask = s.findActiveUpdateTask(deviceIPAddress, authStr, userAuthData); updateStatus.ActiveTask != "" {
+		taskData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, updateStatus.ActiveTask, userAuthData)
+		updateStatus.ActiveTaskState, _ = taskData["TaskState"].(string)
+		percent, _ := taskData["PercentComplete"].(float64)
+		updateStatus.ActiveTaskPercent = uint32(percent)
+	}
+	return updateStatus, statusCode, nil
+}
+
+/* cancelSoftwareUpdate() deletes the active software update task, an empty task URI means no task was found
+ */
+func (s *Server) cancelSoftwareUpdate(deviceIPAddress, authStr string) (taskURI string, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return "", http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	taskURI = s.findActiveUpdateTask(deviceIPAddress, authStr, userAuthData)
+	if taskURI == "" {
+		return "", http.StatusOK, nil
+	}
+	_, statusCode, err = deleteHTTPDataByRfAPI(deviceIPAddress, taskURI, userAuthData, "")
+	if err != nil || (statusCode != http.StatusOK && statusCode != http.StatusAccepted && statusCode != http.StatusNoContent) {
+		logrus.Errorf(ErrCancelUpdateFailed.String(strconv.Itoa(statusCode)))
+		return taskURI, statusCode, errors.New(ErrCancelUpdateFailed.String(strconv.Itoa(statusCode)))
+	}
+	return taskURI, statusCode, nil
+}
+
+/* pushDeviceSoftware() streams a software image to the UpdateService MultipartHttpPushUri of the device and
+ * returns the task monitor URI reported in the Location header
+ */
+func (s *Server) pushDeviceSoftware(deviceIPAddress, authStr, fileName string, targets []string, applyTime string, image io.Reader) (taskURI string, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return "", http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	updateService, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfUpdateService, userAuthData)
+	pushURI, _ := updateService["MultipartHttpPushUri"].(string)
+	if pushURI == "" {
+		logrus.Errorf(ErrMultipartPushNotSupported.String())
+		return "", http.StatusNotImplemented, errors.New(ErrMultipartPushNotSupported.String())
+	}
+	parameters := map[string]interface{}{}
+	parameters["Targets"] = targets
+	if targets == nil {
+		parameters["Targets"] = []string{}
+	}
+	if applyTime != "" {
+		if statusCode, err = s.checkUpdateApplyTime(deviceIPAddress, userAuthData, applyTime); err != nil {
+			return "", statusCode, err
+		}
+		parameters["@Redfish.OperationApplyTime"] = applyTime
+	}
+	response, statusCode, _ := postMultipartHTTPDataByRfAPI(deviceIPAddress, pushURI, userAuthData, parameters, fileName, image)
+	if statusCode != http.StatusAccepted && statusCode != http.StatusOK && statusCode != http.StatusCreated {
+		logrus.Errorf(ErrPushSoftwareFailed.String(strconv.Itoa(statusCode)))
+		return "", statusCode, errors.New(ErrPushSoftwareFailed.String(strconv.Itoa(statusCode)))
+	}
+	return response.Header.Get("Location"), statusCode, nil
+}
+
+type firmwareComponent struct {
+	ID      string
+	Name    string
+	Version string
+	OdataID string
+}
+
+/* getFirmwareInventory() reads every member of the UpdateService FirmwareInventory collection, falling back
+ * to SoftwareInventory for devices that only expose that one
+ */
+func (s *Server) getFirmwareInventory(deviceIPAddress, authStr string) (components []firmwareComponent, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, inventory := range []string{RfFirmwareInventory, RfSoftwareInventory} {
+		members, _, _ := s.getDeviceData(deviceIPAddress, inventory, authStr, 2, "@odata.id")
+		for _, member := range members {
+			memberData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, member, userAuthData)
+			if memberData == nil {
+				continue
+			}
+			component := firmwareComponent{OdataID: member}
+			component.ID, _ = memberData["Id"].(string)
+			component.Name, _ = memberData["Name"].(string)
+			component.Version, _ = memberData["Version"].(string)
+			components = append(components, component)
+		}
+		if len(components) != 0 {
+			return components, http.StatusOK, nil
+		}
+	}
+	logrus.Errorf(ErrGetFirmwareInventoryFailed.String())
+	return nil, http.StatusNotFound, errors.New(ErrGetFirmwareInventoryFailed.String())
+}
+
+//firmwareBanks ...
+type firmwareBanks struct {
+	Manager       string
+	ActiveBank    string
+	ActiveVersion string
+	BackupBank    string
+	BackupVersion string
+}
+
+/* getManagerFirmwareBanks() reads the firmware version of the first manager together with the SoftwareInventory
+ * images its Links.ActiveSoftwareImage and Links.SoftwareImages point at. The first image that is not the active
+ * one is reported as the backup bank, a device linking no other image or no active image leaves the backup bank empty
+ */
+func (s *Server) getManagerFirmwareBanks(deviceIPAddress, authStr string) (banks firmwareBanks, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return banks, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	if len(managerOdataIds) == 0 {
+		logrus.Errorf(ErrManagerFirmwareNotFound.String())
+		return banks, http.StatusNotFound, errors.New(ErrManagerFirmwareNotFound.String())
+	}
+	banks.Manager = managerOdataIds[0]
+	managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, banks.Manager, userAuthData)
+	banks.ActiveVersion, _ = managerData["FirmwareVersion"].(string)
+	links, _ := managerData["Links"].(map[string]interface{})
+	banks.ActiveBank = getOdataID(links, "ActiveSoftwareImage")
+	if banks.ActiveBank != "" {
+		image, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, banks.ActiveBank, userAuthData)
+		if version, _ := image["Version"].(string); version != "" {
+			banks.ActiveVersion = version
+		}
+	}
+	if banks.ActiveVersion == "" {
+		logrus.Errorf(ErrManagerFirmwareNotFound.String())
+		return banks, http.StatusNotFound, errors.New(ErrManagerFirmwareNotFound.String())
+	}
+	if banks.ActiveBank == "" {
+		return banks, http.StatusOK, nil
+	}
+	images, _ := links["SoftwareImages"].([]interface{})
+	for _, member := range images {
+		imageURI, _ := member.(map[string]interface{})["@odata.id"].(string)
+		if imageURI == "" || imageURI == banks.ActiveBank {
+			continue
+		}
+		image, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, imageURI, userAuthData)
+		if image == nil {
+			continue
+		}
+		banks.BackupBank = imageURI
+		banks.BackupVersion, _ = image["Version"].(string)
+		break
+	}
+	return banks, http.StatusOK, nil
+}
+
+/* switchFirmwareBank() patches Links.ActiveSoftwareImage of the first manager to its backup bank, the manager boots
+ * from that bank on its next reset. The switch is refused when the manager does not link its active image, as the
+ * backup bank cannot be told apart from it. The banks returned are the ones read before the switch
+ */
+func (s *Server) switchFirmwareBank(deviceIPAddress, authStr string) (banks firmwareBanks, statusCode int, err error) {
+	banks, statusCode, err = s.getManagerFirmwareBanks(deviceIPAddress, authStr)
+	if err != nil {
+		return banks, statusCode, err
+	}
+	if banks.ActiveBank == "" {
+		logrus.Errorf(ErrActiveFirmwareBankUnknown.String())
+		return banks, http.StatusNotImplemented, errors.New(ErrActiveFirmwareBankUnknown.String())
+	}
+	if banks.BackupBank == "" {
+		logrus.Errorf(ErrSingleFirmwareBank.String())
+		return banks, http.StatusNotImplemented, errors.New(ErrSingleFirmwareBank.String())
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	activeImage := map[string]interface{}{
+		"Links": map[string]interface{}{
+			"ActiveSoftwareImage": map[string]interface{}{"@odata.id": banks.BackupBank},
+		},
+	}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, banks.Manager, userAuthData, activeImage)
+	if statusCode != http.StatusOK && statusCode != http.StatusAccepted && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSwitchFirmwareBankFailed.String(banks.BackupBank, strconv.Itoa(statusCode)))
+		return banks, statusCode, errors.New(ErrSwitchFirmwareBankFailed.String(banks.BackupBank, strconv.Itoa(statusCode)))
+	}
+	return banks, statusCode, nil
+}
+
+/* checkUpdateApplyTime() validates the apply time against the UpdateService @Redfish.OperationApplyTimeSupport values
+ */
+func (s *Server) checkUpdateApplyTime(deviceIPAddress string, userAuthData userAuth, applyTime string) (statusCode int, err error) {
+	updateService, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfUpdateService, userAuthData)
+	applyTimeSupport, _ := updateService["@Redfish.OperationApplyTimeSupport"].(map[string]interface{})
+	supportedValues, _ := applyTimeSupport["SupportedValues"].([]interface{})
+	supported := []string{}
+	for _, value := range supportedValues {
+		if option, ok := value.(string); ok {
+			if option == applyTime {
+				return http.StatusOK, nil
+			}
+			supported = append(supported, option)
+		}
+	}
+	logrus.Errorf(ErrApplyTimeNotsupport.String(applyTime, strings.Join(supported, " ")))
+	return http.StatusBadRequest, errors.New(ErrApplyTimeNotsupport.String(applyTime, strings.Join(supported, " ")))
+}
+
+func (s *Server) sendDeviceSoftwareDownloadURI(deviceIPAddress, authStr, softwareType, URI, applyTime string) (statusCode int, err error) {
 	if len(URI) == 0 {
 		logrus.Errorf("The URI is empty")
 		return http.StatusBadRequest, errors.New("The URI is empty")
@@ -78,6 +381,12 @@ func (s *Server) sendDeviceSoftwareDownloadURI(deviceIPAddress, authStr, softwar
 	ServiceInfo := map[string]interface{}{}
 	body := map[string]interface{}{}
 	ServiceInfo["ImageURI"] = URI
+	if applyTime != "" {
+		if statusCode, err = s.checkUpdateApplyTime(deviceIPAddress, userAuthData, applyTime); err != nil {
+			return statusCode, err
+		}
+		ServiceInfo["@Redfish.OperationApplyTime"] = applyTime
+	}
 	_, body, statusCode, _ = postHTTPDataByRfAPI(deviceIPAddress, softwareUpdateRfAPI, userAuthData, ServiceInfo)
 	switch statusCode {
 	case http.StatusServiceUnavailable:
@@ -0,0 +1,239 @@
+/*Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+const (
+	//RfEventService ...
+	RfEventService = "/redfish/v1/EventService/"
+	//RfEventServiceSubscriptions ...
+	RfEventServiceSubscriptions = "/redfish/v1/EventService/Subscriptions/"
+	//RfSubscriptionCheckInterval is the period between two checks of the subscriptions of a polled device
+	RfSubscriptionCheckInterval = 5 * time.Minute
+)
+
+/* setSyslogTarget() subscribes the syslog collector at host:port to the device events through a Redfish
+ * EventDestination of SubscriptionType "Syslog". An existing syslog EventDestination of the device is patched to the
+ * new destination, when the device refuses to patch it the EventDestination is deleted and created again
+ */
+func (s *Server) setSyslogTarget(deviceIPAddress, authStr, host string, port uint32) (statusCode int, err error) {
+	if len(host) == 0 || port == 0 || port > 65535 {
+		logrus.Errorf(ErrSyslogTargetInvalid.String())
+		return http.StatusBadRequest, errors.New(ErrSyslogTargetInvalid.String())
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	eventService, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfEventService, userAuthData)
+	if statusCode != http.StatusOK || eventService == nil || eventService["Subscriptions"] == nil {
+		logrus.Errorf(ErrSyslogNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrSyslogNotSupported.String())
+	}
+	if enabled, ok := eventService["ServiceEnabled"].(bool); ok && !enabled {
+		logrus.Errorf(ErrEventServiceDisabled.String())
+		return http.StatusConflict, errors.New(ErrEventServiceDisabled.String())
+	}
+	destination := "syslog://" + net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+	subscriptionID, previous := s.findSyslogSubscription(deviceIPAddress, userAuthData)
+	if subscriptionID != "" && previous == destination {
+		s.recordSyslogTarget(deviceIPAddress, previous, destination)
+		return http.StatusOK, nil
+	}
+	if subscriptionID != "" {
+		_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, subscriptionID, userAuthData, map[string]interface{}{"Destination": destination})
+		if statusCode == http.StatusOK || statusCode == http.StatusAccepted || statusCode == http.StatusNoContent {
+			s.recordSyslogTarget(deviceIPAddress, previous, destination)
+			return statusCode, nil
+		}
+		_, statusCode, _ = deleteHTTPDataByRfAPI(deviceIPAddress, subscriptionID, userAuthData, "")
+		if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+			logrus.Errorf(ErrSetSyslogTargetFailed.String(strconv.Itoa(statusCode)))
+			return statusCode, errors.New(ErrSetSyslogTargetFailed.String(strconv.Itoa(statusCode)))
+		}
+	}
+	statusCode = postSyslogSubscription(deviceIPAddress, userAuthData, destination)
+	switch statusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		s.recordSyslogTarget(deviceIPAddress, previous, destination)
+		return statusCode, nil
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		logrus.Errorf(ErrSyslogNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrSyslogNotSupported.String())
+	}
+	logrus.Errorf(ErrSetSyslogTargetFailed.String(strconv.Itoa(statusCode)))
+	return statusCode, errors.New(ErrSetSyslogTargetFailed.String(strconv.Itoa(statusCode)))
+}
+
+/* findSyslogSubscription() returns the @odata.id and Destination of the first EventDestination of the device with
+ * SubscriptionType "Syslog", both are empty when the device has none
+ */
+func (s *Server) findSyslogSubscription(deviceIPAddress string, userAuthData userAuth) (odataID, destination string) {
+	subscriptions, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfEventServiceSubscriptions, userAuthData)
+	if statusCode != http.StatusOK || subscriptions == nil {
+		return "", ""
+	}
+	for _, subscriptionID := range s.getRedfishDeviceData(subscriptions, 2, "@odata.id") {
+		subscription, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, subscriptionID, userAuthData)
+		if subscriptionType, _ := subscription["SubscriptionType"].(string); subscriptionType == "Syslog" {
+			destination, _ = subscription["Destination"].(string)
+			return subscriptionID, destination
+		}
+	}
+	return "", ""
+}
+
+func postSyslogSubscription(deviceIPAddress string, userAuthData userAuth, destination string) (statusCode int) {
+	subscription := map[string]interface{}{}
+	subscription["Destination"] = destination
+	subscription["SubscriptionType"] = "Syslog"
+	subscription["Protocol"] = "SyslogUDP"
+	_, _, statusCode, _ = postHTTPDataByRfAPI(deviceIPAddress, RfEventServiceSubscriptions, userAuthData, subscription)
+	return statusCode
+}
+
+/* recordSyslogTarget() remembers destination for the re-creation of lost subscriptions, the destination it replaced
+ * on the device is forgotten
+ */
+func (s *Server) recordSyslogTarget(deviceIPAddress, replaced, destination string) {
+	dev := s.devicemap[deviceIPAddress]
+	dev.SyslogLock.Lock()
+	defer dev.SyslogLock.Unlock()
+	targets := []string{}
+	for _, target := range dev.SyslogTargets {
+		if target != replaced && target != destination {
+			targets = append(targets, target)
+		}
+	}
+	dev.SyslogTargets = append(targets, destination)
+}
+
+/* getSyslogTargets() returns a copy of the syslog targets registered by setsyslog, so the device can be queried
+ * without holding SyslogLock
+ */
+func (s *Server) getSyslogTargets(deviceIPAddress string) []string {
+	dev := s.devicemap[deviceIPAddress]
+	dev.SyslogLock.Lock()
+	defer dev.SyslogLock.Unlock()
+	return append([]string(nil), dev.SyslogTargets...)
+}
+
+/* reconcileSubscriptions() re-creates, once per RfSubscriptionCheckInterval, the syslog subscriptions registered
+ * by setsyslog which the device lost, e.g. in a reboot
+ */
+func (s *Server) reconcileSubscriptions(deviceIPAddress string, userAuthData userAuth) {
+	dev := s.devicemap[deviceIPAddress]
+	if dev == nil || !GlobalConfig.AutoResubscribe {
+		return
+	}
+	dev.SyslogLock.Lock()
+	if len(dev.SyslogTargets) == 0 || time.Since(dev.SubscriptionsCheckedAt) < RfSubscriptionCheckInterval {
+		dev.SyslogLock.Unlock()
+		return
+	}
+	dev.SubscriptionsCheckedAt = time.Now()
+	syslogTargets := append([]string(nil), dev.SyslogTargets...)
+	dev.SyslogLock.Unlock()
+	subscriptions, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfEventServiceSubscriptions, userAuthData)
+	if statusCode != http.StatusOK || subscriptions == nil {
+		return
+	}
+	destinations := map[string]bool{}
+	for _, odataID := range s.getRedfishDeviceData(subscriptions, 2, "@odata.id") {
+		subscription, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, odataID, userAuthData)
+		if destination, ok := subscription["Destination"].(string); ok {
+			destinations[destination] = true
+		}
+	}
+	for _, target := range syslogTargets {
+		if destinations[target] {
+			continue
+		}
+		statusCode = postSyslogSubscription(deviceIPAddress, userAuthData, target)
+		if statusCode != http.StatusOK && statusCode != http.StatusCreated && statusCode != http.StatusNoContent {
+			logrus.WithFields(logrus.Fields{
+				"IP address:port": deviceIPAddress,
+				"Destination":     target}).Errorf(ErrSetSyslogTargetFailed.String(strconv.Itoa(statusCode)))
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"IP address:port": deviceIPAddress,
+			"Destination":     target}).Warn("lost syslog subscription re-created")
+	}
+}
+
+/* clearSyslogTargets() deletes the subscriptions of device whose destination was registered by setsyslog and forgets
+ * the targets, so they are not re-created, the number of deleted subscriptions is returned
+ */
+func (s *Server) clearSyslogTargets(deviceIPAddress, authStr string) (deleted uint32, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return 0, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	syslogTargets := s.getSyslogTargets(deviceIPAddress)
+	if len(syslogTargets) == 0 {
+		return 0, http.StatusOK, nil
+	}
+	targets := map[string]bool{}
+	for _, target := range syslogTargets {
+		targets[target] = true
+	}
+	subscriptions, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfEventServiceSubscriptions, userAuthData)
+	if statusCode != http.StatusOK || subscriptions == nil {
+		logrus.Errorf(ErrDeleteSubscriptionFailed.String(strconv.Itoa(statusCode)))
+		return 0, statusCode, errors.New(ErrDeleteSubscriptionFailed.String(strconv.Itoa(statusCode)))
+	}
+	for _, odataID := range s.getRedfishDeviceData(subscriptions, 2, "@odata.id") {
+		subscription, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, odataID, userAuthData)
+		if destination, _ := subscription["Destination"].(string); !targets[destination] {
+			continue
+		}
+		_, statusCode, _ = deleteHTTPDataByRfAPI(deviceIPAddress, odataID, userAuthData, "")
+		if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+			logrus.Errorf(ErrDeleteSubscriptionFailed.String(strconv.Itoa(statusCode)))
+			return deleted, statusCode, errors.New(ErrDeleteSubscriptionFailed.String(strconv.Itoa(statusCode)))
+		}
+		deleted++
+	}
+	dev := s.devicemap[deviceIPAddress]
+	dev.SyslogLock.Lock()
+	remaining := []string{}
+	for _, target := range dev.SyslogTargets {
+		if !targets[target] {
+			remaining = append(remaining, target)
+		}
+	}
+	dev.SyslogTargets = remaining
+	dev.SyslogLock.Unlock()
+	return deleted, http.StatusOK, nil
+}
@@ -26,8 +26,10 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	logrus "github.com/sirupsen/logrus"
 )
@@ -86,6 +88,65 @@ func (s *Server) resetDeviceSystem(deviceIPAddress, authStr, resetType string) (
 	return statusNum, nil
 }
 
+/* resetBmcToDefaults() posts the Manager.ResetToDefaults action of the first manager of the device that has it, after
+ * checking the reset type against the allowable values of the action and of its @Redfish.ActionInfo
+ */
+func (s *Server) resetBmcToDefaults(deviceIPAddress, authStr, resetType string) (statusNum int, err error) {
+	if len(resetType) == 0 {
+		logrus.Errorf(ErrResetTypeEmpty.String())
+		return http.StatusBadRequest, errors.New(ErrResetTypeEmpty.String())
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	var managerOdataID string
+	var resetToDefaults map[string]interface{}
+	for _, odataID := range managerOdataIds {
+		managerData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, odataID, userAuthData)
+		actions, _ := managerData["Actions"].(map[string]interface{})
+		if action, ok := actions["#Manager.ResetToDefaults"].(map[string]interface{}); ok {
+			managerOdataID, resetToDefaults = odataID, action
+			break
+		}
+	}
+	if resetToDefaults == nil {
+		logrus.Errorf(ErrResetToDefaultsNotSupported.String())
+		return http.StatusNotImplemented, errors.New(ErrResetToDefaultsNotSupported.String())
+	}
+	target, _ := resetToDefaults["target"].(string)
+	if target == "" {
+		target = managerOdataID + "/Actions/Manager.ResetToDefaults"
+	}
+	resetTypeAllowValue := []string{}
+	for _, parameter := range actionParameters(deviceIPAddress, userAuthData, resetToDefaults) {
+		if parameter.Name == "ResetType" {
+			resetTypeAllowValue = parameter.AllowableValues
+		}
+	}
+	found := false
+	for _, option := range resetTypeAllowValue {
+		if option == resetType {
+			found = true
+			break
+		}
+	}
+	if !found {
+		logrus.Errorf(ErrResetTypeNotsupport.String(resetType, strings.Join(resetTypeAllowValue, " ")))
+		return http.StatusBadRequest, errors.New(ErrResetTypeNotsupport.String(resetType, strings.Join(resetTypeAllowValue, " ")))
+	}
+	resetInfo := map[string]interface{}{}
+	resetInfo["ResetType"] = resetType
+	_, _, statusNum, _ = postHTTPDataByRfAPI(deviceIPAddress, target, userAuthData, resetInfo)
+	if statusNum != http.StatusOK && statusNum != http.StatusAccepted && statusNum != http.StatusNoContent {
+		logrus.Errorf(ErrResetToDefaultsFailed.String(strconv.Itoa(statusNum)))
+		return statusNum, errors.New(ErrResetToDefaultsFailed.String(strconv.Itoa(statusNum)))
+	}
+	return statusNum, nil
+}
+
 func (s *Server) getDeviceTemperature(deviceIPAddress, authStr string) (retData []string, statusCode int, err error) {
 	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
 	if (userAuthData == userAuth{}) {
@@ -93,6 +154,7 @@ func (s *Server) getDeviceTemperature(deviceIPAddress, authStr string) (retData
 		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
 	}
 	mapData := make(map[string]interface{})
+	mapData["SchemaVersion"] = SchemaVersion
 	dataSlice := []string{}
 	chassisOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfChassis, authStr, 2, "@odata.id")
 	for _, chassisOdataID := range chassisOdataIds {
@@ -157,6 +219,59 @@ func (s *Server) getDeviceTemperature(deviceIPAddress, authStr string) (retData
 	return dataSlice, statusCode, nil
 }
 
+//temperatureThreshold holds the reading and the shutdown thresholds of a temperature sensor, 0 when not reported
+type temperatureThreshold struct {
+	Chassis                string
+	MemberID               string
+	Name                   string
+	ReadingCelsius         float64
+	UpperThresholdCritical float64
+	UpperThresholdFatal    float64
+	HeadroomCelsius        float64
+}
+
+/* getTemperatureThresholds() reads ReadingCelsius, UpperThresholdCritical and UpperThresholdFatal of every
+ * temperature sensor of the chassis Thermal resources, the headroom is measured from the reading up to the fatal
+ * threshold or to the critical one when the sensor has no fatal threshold
+ */
+func (s *Server) getTemperatureThresholds(deviceIPAddress, authStr string) (thresholds []temperatureThreshold, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	chassisOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfChassis, authStr, 2, "@odata.id")
+	for _, chassisOdataID := range chassisOdataIds {
+		thermalData, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, chassisOdataID+"/Thermal", userAuthData)
+		if thermalData == nil {
+			logrus.Errorf(ErrGetTemperDataFailed.String())
+			return nil, statusCode, errors.New(ErrGetTemperDataFailed.String())
+		}
+		temperatures, _ := thermalData["Temperatures"].([]interface{})
+		for _, temperature := range temperatures {
+			sensor, ok := temperature.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			threshold := temperatureThreshold{Chassis: chassisOdataID}
+			threshold.MemberID, _ = sensor["MemberId"].(string)
+			threshold.Name, _ = sensor["Name"].(string)
+			threshold.ReadingCelsius, _ = sensor["ReadingCelsius"].(float64)
+			threshold.UpperThresholdCritical, _ = sensor["UpperThresholdCritical"].(float64)
+			threshold.UpperThresholdFatal, _ = sensor["UpperThresholdFatal"].(float64)
+			shutdown := threshold.UpperThresholdFatal
+			if shutdown == 0 {
+				shutdown = threshold.UpperThresholdCritical
+			}
+			if shutdown != 0 {
+				threshold.HeadroomCelsius = shutdown - threshold.ReadingCelsius
+			}
+			thresholds = append(thresholds, threshold)
+		}
+	}
+	return thresholds, http.StatusOK, nil
+}
+
 //setDeviceTemperatureForEvent ...
 func (s *Server) setDeviceTemperatureForEvent(deviceIPAddress, authStr, memberID string, upperThresholdNonCritical uint32, lowerThresholdNonCritical uint32) (statusCode int, err error) {
 	if upperThresholdNonCritical <= lowerThresholdNonCritical {
@@ -198,3 +313,459 @@ func (s *Server) setDeviceTemperatureForEvent(deviceIPAddress, authStr, memberID
 	}
 	return
 }
+
+/* getManagerDateTime() returns the DateTime and DateTimeLocalOffset of the first manager of the device
+ */
+func (s *Server) getManagerDateTime(deviceIPAddress, authStr string) (dateTime, localOffset string, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return "", "", http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	if len(managerOdataIds) == 0 {
+		logrus.Errorf(ErrDateTimeNotSupported.String())
+		return "", "", http.StatusNotFound, errors.New(ErrDateTimeNotSupported.String())
+	}
+	managerData, statusCode, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData)
+	dateTime, ok := managerData["DateTime"].(string)
+	if !ok {
+		logrus.Errorf(ErrDateTimeNotSupported.String())
+		return "", "", http.StatusNotFound, errors.New(ErrDateTimeNotSupported.String())
+	}
+	localOffset, _ = managerData["DateTimeLocalOffset"].(string)
+	return dateTime, localOffset, statusCode, nil
+}
+
+/* setManagerDateTime() patches the DateTime and, when given, the DateTimeLocalOffset of the first manager of the device
+ */
+func (s *Server) setManagerDateTime(deviceIPAddress, authStr, dateTime, localOffset string) (statusCode int, err error) {
+	if _, err := time.Parse(time.RFC3339, dateTime); err != nil {
+		logrus.Errorf(ErrDateTimeInvalid.String(dateTime))
+		return http.StatusBadRequest, errors.New(ErrDateTimeInvalid.String(dateTime))
+	}
+	if localOffset != "" {
+		if _, err := time.Parse("-07:00", localOffset); err != nil {
+			logrus.Errorf(ErrDateTimeOffsetInvalid.String(localOffset))
+			return http.StatusBadRequest, errors.New(ErrDateTimeOffsetInvalid.String(localOffset))
+		}
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	managerOdataIds, _, _ := s.getDeviceData(deviceIPAddress, RfManager, authStr, 2, "@odata.id")
+	if len(managerOdataIds) == 0 {
+		logrus.Errorf(ErrDateTimeNotSupported.String())
+		return http.StatusNotFound, errors.New(ErrDateTimeNotSupported.String())
+	}
+	dateTimeInfo := map[string]interface{}{}
+	dateTimeInfo["DateTime"] = dateTime
+	if localOffset != "" {
+		dateTimeInfo["DateTimeLocalOffset"] = localOffset
+	}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, managerOdataIds[0], userAuthData, dateTimeInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetDateTimeFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrSetDateTimeFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
+
+//bootPolicy holds the automatic boot retry and AC power restore settings of a ComputerSystem
+type bootPolicy struct {
+	AutomaticRetryConfig        string
+	PowerRestorePolicy          string
+	AutomaticRetryConfigAllowed []string
+	PowerRestorePolicyAllowed   []string
+}
+
+//defaultAutomaticRetryConfigs and defaultPowerRestorePolicies are the values of the Redfish schema, used when the device lists none
+var (
+	defaultAutomaticRetryConfigs = []string{"Disabled", "RetryAttempts", "RetryAlways"}
+	defaultPowerRestorePolicies  = []string{"AlwaysOn", "AlwaysOff", "LastState"}
+)
+
+func getAllowableValues(data map[string]interface{}, key string) (values []string) {
+	allowValues, _ := data[key+"@Redfish.AllowableValues"].([]interface{})
+	for _, value := range allowValues {
+		if option, ok := value.(string); ok {
+			values = append(values, option)
+		}
+	}
+	return values
+}
+
+func (s *Server) getFirstSystem(deviceIPAddress string, userAuthData userAuth) (odataID string, systemData map[string]interface{}) {
+	systems, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfSystems, userAuthData)
+	systemOdataIds := s.getRedfishDeviceData(systems, 2, "@odata.id")
+	if len(systemOdataIds) == 0 {
+		return "", nil
+	}
+	systemData, _, _ = getHTTPBodyDataByRfAPI(deviceIPAddress, systemOdataIds[0], userAuthData)
+	return systemOdataIds[0], systemData
+}
+
+/* getBootPolicy() returns Boot.AutomaticRetryConfig and PowerRestorePolicy of the first system of the device with their allowable values
+ */
+func (s *Server) getBootPolicy(deviceIPAddress, authStr string) (policy bootPolicy, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return policy, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	_, systemData := s.getFirstSystem(deviceIPAddress, userAuthData)
+	if systemData == nil {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return policy, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	boot, _ := systemData["Boot"].(map[string]interface{})
+	policy.AutomaticRetryConfig, _ = boot["AutomaticRetryConfig"].(string)
+	policy.AutomaticRetryConfigAllowed = getAllowableValues(boot, "AutomaticRetryConfig")
+	if len(policy.AutomaticRetryConfigAllowed) == 0 && policy.AutomaticRetryConfig != "" {
+		policy.AutomaticRetryConfigAllowed = defaultAutomaticRetryConfigs
+	}
+	policy.PowerRestorePolicy, _ = systemData["PowerRestorePolicy"].(string)
+	policy.PowerRestorePolicyAllowed = getAllowableValues(systemData, "PowerRestorePolicy")
+	if len(policy.PowerRestorePolicyAllowed) == 0 && policy.PowerRestorePolicy != "" {
+		policy.PowerRestorePolicyAllowed = defaultPowerRestorePolicies
+	}
+	return policy, http.StatusOK, nil
+}
+
+/* setBootPolicy() patches Boot.AutomaticRetryConfig and/or PowerRestorePolicy of the first system of the device,
+ * an empty value leaves the setting unchanged
+ */
+func (s *Server) setBootPolicy(deviceIPAddress, authStr, automaticRetryConfig, powerRestorePolicy string) (statusCode int, err error) {
+	if automaticRetryConfig == "" && powerRestorePolicy == "" {
+		logrus.Errorf(ErrBootPolicyEmpty.String())
+		return http.StatusBadRequest, errors.New(ErrBootPolicyEmpty.String())
+	}
+	policy, statusCode, err := s.getBootPolicy(deviceIPAddress, authStr)
+	if err != nil {
+		return statusCode, err
+	}
+	checks := [][]string{{"AutomaticRetryConfig", automaticRetryConfig}, {"PowerRestorePolicy", powerRestorePolicy}}
+	allowed := [][]string{policy.AutomaticRetryConfigAllowed, policy.PowerRestorePolicyAllowed}
+	for i, check := range checks {
+		if check[1] == "" {
+			continue
+		}
+		found := false
+		for _, option := range allowed[i] {
+			if option == check[1] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			logrus.Errorf(ErrBootPolicyNotSupport.String(check[0], check[1], strings.Join(allowed[i], " ")))
+			return http.StatusBadRequest, errors.New(ErrBootPolicyNotSupport.String(check[0], check[1], strings.Join(allowed[i], " ")))
+		}
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	systemOdataID, _ := s.getFirstSystem(deviceIPAddress, userAuthData)
+	policyInfo := map[string]interface{}{}
+	if automaticRetryConfig != "" {
+		policyInfo["Boot"] = map[string]interface{}{"AutomaticRetryConfig": automaticRetryConfig}
+	}
+	if powerRestorePolicy != "" {
+		policyInfo["PowerRestorePolicy"] = powerRestorePolicy
+	}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, systemOdataID, userAuthData, policyInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetBootPolicyFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrSetBootPolicyFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
+
+//bootOverride holds the boot source override of a ComputerSystem
+type bootOverride struct {
+	Enabled string
+	Target  string
+	Mode    string
+}
+
+/* clearBootOverride() sets Boot.BootSourceOverrideEnabled of the first system of the device back to "Disabled" so
+ * the host boots from its boot order again, it returns the override found before, which is left untouched when
+ * already disabled
+ */
+func (s *Server) clearBootOverride(deviceIPAddress, authStr string) (override bootOverride, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return override, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	systemOdataID, systemData := s.getFirstSystem(deviceIPAddress, userAuthData)
+	if systemData == nil {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return override, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	boot, _ := systemData["Boot"].(map[string]interface{})
+	if _, ok := boot["BootSourceOverrideEnabled"]; !ok {
+		logrus.Errorf(ErrBootOverrideNotSupported.String())
+		return override, http.StatusNotImplemented, errors.New(ErrBootOverrideNotSupported.String())
+	}
+	override.Enabled, _ = boot["BootSourceOverrideEnabled"].(string)
+	override.Target, _ = boot["BootSourceOverrideTarget"].(string)
+	override.Mode, _ = boot["BootSourceOverrideMode"].(string)
+	if override.Enabled == "Disabled" {
+		return override, http.StatusOK, nil
+	}
+	bootInfo := map[string]interface{}{"Boot": map[string]interface{}{"BootSourceOverrideEnabled": "Disabled"}}
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, systemOdataID, userAuthData, bootInfo)
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrClearBootOverrideFailed.String(strconv.Itoa(statusCode)))
+		return override, statusCode, errors.New(ErrClearBootOverrideFailed.String(strconv.Itoa(statusCode)))
+	}
+	return override, statusCode, nil
+}
+
+//bootOption holds an entry of the persistent boot order of a ComputerSystem
+type bootOption struct {
+	Reference   string
+	DisplayName string
+	OdataID     string
+}
+
+/* getBootOrder() reads Boot.BootOrder of the first system and names each reference by the DisplayName of the
+ * BootOption with that BootOptionReference, a device reporting only Boot.AliasBootOrder returns the aliases
+ */
+func (s *Server) getBootOrder(deviceIPAddress, authStr string) (order []bootOption, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	_, systemData := s.getFirstSystem(deviceIPAddress, userAuthData)
+	if systemData == nil {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return nil, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	boot, _ := systemData["Boot"].(map[string]interface{})
+	references, ok := boot["BootOrder"].([]interface{})
+	if !ok {
+		aliases, ok := boot["AliasBootOrder"].([]interface{})
+		if !ok {
+			logrus.Errorf(ErrBootOrderNotSupported.String())
+			return nil, http.StatusNotImplemented, errors.New(ErrBootOrderNotSupported.String())
+		}
+		for _, alias := range aliases {
+			name, _ := alias.(string)
+			order = append(order, bootOption{Reference: name, DisplayName: name})
+		}
+		return order, http.StatusOK, nil
+	}
+	bootOptionsURI := getOdataID(systemData, "BootOptions")
+	if bootOptionsURI == "" {
+		bootOptionsURI = getOdataID(boot, "BootOptions")
+	}
+	options := map[string]bootOption{}
+	if bootOptionsURI != "" {
+		bootOptions, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, bootOptionsURI, userAuthData)
+		for _, member := range s.getRedfishDeviceData(bootOptions, 2, "@odata.id") {
+			optionData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, member, userAuthData)
+			reference, _ := optionData["BootOptionReference"].(string)
+			if reference == "" {
+				continue
+			}
+			option := bootOption{Reference: reference, OdataID: member}
+			option.DisplayName, _ = optionData["DisplayName"].(string)
+			options[reference] = option
+		}
+	}
+	for _, reference := range references {
+		name, _ := reference.(string)
+		option, ok := options[name]
+		if !ok {
+			option = bootOption{Reference: name}
+		}
+		order = append(order, option)
+	}
+	return order, http.StatusOK, nil
+}
+
+//systemCapacity holds the installed memory and logical processors summed over the systems of a device
+type systemCapacity struct {
+	SystemCount           uint32
+	TotalMemoryGiB        float64
+	LogicalProcessorCount uint32
+}
+
+/* getSystemCapacity() reads MemorySummary.TotalSystemMemoryGiB and ProcessorSummary.LogicalProcessorCount of every
+ * ComputerSystem of the device and sums them, a summary missing from a system counts as zero
+ */
+func (s *Server) getSystemCapacity(deviceIPAddress, authStr string) (capacity systemCapacity, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return capacity, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	systems, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, RfSystems, userAuthData)
+	systemOdataIds := s.getRedfishDeviceData(systems, 2, "@odata.id")
+	if len(systemOdataIds) == 0 {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return capacity, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	for _, systemOdataID := range systemOdataIds {
+		systemData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, systemOdataID, userAuthData)
+		if systemData == nil {
+			continue
+		}
+		capacity.SystemCount++
+		memorySummary, _ := systemData["MemorySummary"].(map[string]interface{})
+		memory, _ := memorySummary["TotalSystemMemoryGiB"].(float64)
+		capacity.TotalMemoryGiB += memory
+		processorSummary, _ := systemData["ProcessorSummary"].(map[string]interface{})
+		logicalCount, _ := processorSummary["LogicalProcessorCount"].(float64)
+		capacity.LogicalProcessorCount += uint32(logicalCount)
+	}
+	if capacity.SystemCount == 0 {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return capacity, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	return capacity, http.StatusOK, nil
+}
+
+//bootProgress holds the BootProgress and power state of a ComputerSystem
+type bootProgress struct {
+	LastState     string
+	OemLastState  string
+	LastStateTime string
+	PowerState    string
+}
+
+/* getBootProgress() returns BootProgress of the first system of the device
+ */
+func (s *Server) getBootProgress(deviceIPAddress, authStr string) (progress bootProgress, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return progress, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	_, systemData := s.getFirstSystem(deviceIPAddress, userAuthData)
+	if systemData == nil {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return progress, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	boot, ok := systemData["BootProgress"].(map[string]interface{})
+	if !ok {
+		logrus.Errorf(ErrBootProgressNotSupported.String())
+		return progress, http.StatusNotImplemented, errors.New(ErrBootProgressNotSupported.String())
+	}
+	progress.LastState, _ = boot["LastState"].(string)
+	progress.OemLastState, _ = boot["OemLastState"].(string)
+	progress.LastStateTime, _ = boot["LastStateTime"].(string)
+	progress.PowerState, _ = systemData["PowerState"].(string)
+	return progress, http.StatusOK, nil
+}
+
+//defaultWatchdogTimeoutActions are the TimeoutAction values of the Redfish schema, used when the device lists none
+var defaultWatchdogTimeoutActions = []string{"None", "ResetSystem", "PowerCycle", "PowerDown"}
+
+//hostWatchdog holds the HostWatchdogTimer of a ComputerSystem, the timeout is only known when an OEM property exposes it
+type hostWatchdog struct {
+	FunctionEnabled      bool
+	TimeoutAction        string
+	TimeoutActionAllowed []string
+	WarningAction        string
+	TimeoutSeconds       uint32
+	TimeoutOemVendor     string
+	TimeoutOemName       string
+	State                string
+	Health               string
+}
+
+/* getWatchdog() returns HostWatchdogTimer of the first system of the device, the timeout is read from the first
+ * numeric OEM property of the timer named like "Timeout"
+ */
+func (s *Server) getWatchdog(deviceIPAddress, authStr string) (watchdog hostWatchdog, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return watchdog, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	_, systemData := s.getFirstSystem(deviceIPAddress, userAuthData)
+	if systemData == nil {
+		logrus.Errorf(ErrSystemNotFound.String())
+		return watchdog, http.StatusNotFound, errors.New(ErrSystemNotFound.String())
+	}
+	timer, ok := systemData["HostWatchdogTimer"].(map[string]interface{})
+	if !ok {
+		logrus.Errorf(ErrWatchdogNotSupported.String())
+		return watchdog, http.StatusNotImplemented, errors.New(ErrWatchdogNotSupported.String())
+	}
+	watchdog.FunctionEnabled, _ = timer["FunctionEnabled"].(bool)
+	watchdog.TimeoutAction, _ = timer["TimeoutAction"].(string)
+	watchdog.TimeoutActionAllowed = getAllowableValues(timer, "TimeoutAction")
+	if len(watchdog.TimeoutActionAllowed) == 0 {
+		watchdog.TimeoutActionAllowed = defaultWatchdogTimeoutActions
+	}
+	watchdog.WarningAction, _ = timer["WarningAction"].(string)
+	timerStatus, _ := timer["Status"].(map[string]interface{})
+	watchdog.State, _ = timerStatus["State"].(string)
+	watchdog.Health, _ = timerStatus["Health"].(string)
+	oem, _ := timer["Oem"].(map[string]interface{})
+	vendors := []string{}
+	for vendor := range oem {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+	for _, vendor := range vendors {
+		vendorData, _ := oem[vendor].(map[string]interface{})
+		names := []string{}
+		for name := range vendorData {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if seconds, ok := vendorData[name].(float64); ok && strings.Contains(strings.ToLower(name), "timeout") {
+				watchdog.TimeoutSeconds, watchdog.TimeoutOemVendor, watchdog.TimeoutOemName = uint32(seconds), vendor, name
+				return watchdog, http.StatusOK, nil
+			}
+		}
+	}
+	return watchdog, http.StatusOK, nil
+}
+
+/* setWatchdog() patches FunctionEnabled and, when given, TimeoutAction and the OEM timeout of the HostWatchdogTimer
+ * of the first system of the device
+ */
+func (s *Server) setWatchdog(deviceIPAddress, authStr string, enabled bool, timeoutAction string, timeoutSeconds uint32) (statusCode int, err error) {
+	watchdog, statusCode, err := s.getWatchdog(deviceIPAddress, authStr)
+	if err != nil {
+		return statusCode, err
+	}
+	timer := map[string]interface{}{"FunctionEnabled": enabled}
+	if timeoutAction != "" {
+		found := false
+		for _, option := range watchdog.TimeoutActionAllowed {
+			if option == timeoutAction {
+				found = true
+				break
+			}
+		}
+		if !found {
+			logrus.Errorf(ErrWatchdogActionNotSupport.String(timeoutAction, strings.Join(watchdog.TimeoutActionAllowed, " ")))
+			return http.StatusBadRequest, errors.New(ErrWatchdogActionNotSupport.String(timeoutAction, strings.Join(watchdog.TimeoutActionAllowed, " ")))
+		}
+		timer["TimeoutAction"] = timeoutAction
+	}
+	if timeoutSeconds != 0 {
+		if watchdog.TimeoutOemName == "" {
+			logrus.Errorf(ErrWatchdogTimeoutNotSupported.String())
+			return http.StatusNotImplemented, errors.New(ErrWatchdogTimeoutNotSupported.String())
+		}
+		timer["Oem"] = map[string]interface{}{watchdog.TimeoutOemVendor: map[string]interface{}{watchdog.TimeoutOemName: timeoutSeconds}}
+	}
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	systemOdataID, _ := s.getFirstSystem(deviceIPAddress, userAuthData)
+	_, _, statusCode, _ = patchHTTPDataByRfAPI(deviceIPAddress, systemOdataID, userAuthData, map[string]interface{}{"HostWatchdogTimer": timer})
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		logrus.Errorf(ErrSetWatchdogFailed.String(strconv.Itoa(statusCode)))
+		return statusCode, errors.New(ErrSetWatchdogFailed.String(strconv.Itoa(statusCode)))
+	}
+	return statusCode, nil
+}
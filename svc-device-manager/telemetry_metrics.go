@@ -0,0 +1,270 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// metricFamilies describes the metrics exposed by /metrics, in exposition order
+var metricFamilies = [][]string{
+	{"devicemanager_device_degraded", "1 when polling of the device failed repeatedly"},
+	{"devicemanager_power_consumed_watts", "Last sampled power consumption of the device"},
+	{"devicemanager_temperature_celsius", "Last polled temperature sensor reading"},
+	{"devicemanager_cpu_utilization_percent", "Last polled processor bandwidth utilization of the ProcessorMetrics resource"},
+	{"devicemanager_memory_utilization_percent", "Last polled memory capacity utilization of the MemoryMetrics resource"},
+	{"devicemanager_memory_bandwidth_percent", "Last polled memory bandwidth utilization of the MemoryMetrics resource"},
+	{"devicemanager_processor_count", "Number of processors of the system"},
+	{"devicemanager_logical_processor_count", "Number of logical processors of the system"},
+	{"devicemanager_memory_gib", "Total system memory of the system"},
+	{"devicemanager_drive_capacity_bytes", "Capacity of the drive"},
+}
+
+/* cachePolledData() keeps the last document polled from a Redfish resource of the device for the metrics endpoint
+ */
+func (s *Server) cachePolledData(deviceIPAddress, resource, data string) {
+	dev := s.devicemap[deviceIPAddress]
+	if dev == nil {
+		return
+	}
+	dev.PolledDataLock.Lock()
+	defer dev.PolledDataLock.Unlock()
+	if dev.PolledData == nil {
+		dev.PolledData = make(map[string]string)
+	}
+	dev.PolledData[resource] = data
+}
+
+func metricLabels(labels ...string) string {
+	pairs := []string{}
+	for i := 0; i+1 < len(labels); i += 2 {
+		value := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(labels[i+1])
+		pairs = append(pairs, labels[i]+"=\""+value+"\"")
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+/* collectDeviceMetrics() turns the cached polled documents and power samples of a device into metric samples
+ * keyed by metric name, no request is sent to the device
+ */
+func collectDeviceMetrics(deviceIPAddress string, dev *device, samples map[string][]string) {
+	add := func(metric string, value float64, labels ...string) {
+		labels = append([]string{"device", deviceIPAddress}, labels...)
+		samples[metric] = append(samples[metric], metric+metricLabels(labels...)+" "+strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	degraded := 0.0
+	if devicePollState(dev) == DeviceStateDegraded {
+		degraded = 1
+	}
+	add("devicemanager_device_degraded", degraded)
+	dev.PowerLock.Lock()
+	if len(dev.PowerHistory) != 0 {
+		add("devicemanager_power_consumed_watts", dev.PowerHistory[len(dev.PowerHistory)-1].PowerConsumedWatts)
+	}
+	dev.PowerLock.Unlock()
+	dev.PolledDataLock.Lock()
+	defer dev.PolledDataLock.Unlock()
+	resources := []string{}
+	for resource := range dev.PolledData {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	for _, resource := range resources {
+		data := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(dev.PolledData[resource]), &data); err != nil {
+			continue
+		}
+		temperatures, _ := data["Temperatures"].([]interface{})
+		for _, temperature := range temperatures {
+			sensor, _ := temperature.(map[string]interface{})
+			if reading, ok := sensor["ReadingCelsius"].(float64); ok {
+				name, _ := sensor["Name"].(string)
+				add("devicemanager_temperature_celsius", reading, "sensor", name)
+			}
+		}
+		odataType, _ := data["@odata.type"].(string)
+		metrics := []string{"resource", resource}
+		if strings.Contains(odataType, "ProcessorMetrics") {
+			if utilization, ok := data["BandwidthPercent"].(float64); ok {
+				add("devicemanager_cpu_utilization_percent", utilization, metrics...)
+			}
+		}
+		if strings.Contains(odataType, "MemoryMetrics") {
+			if utilization, ok := data["CapacityUtilizationPercent"].(float64); ok {
+				add("devicemanager_memory_utilization_percent", utilization, metrics...)
+			}
+			if utilization, ok := data["BandwidthPercent"].(float64); ok {
+				add("devicemanager_memory_bandwidth_percent", utilization, metrics...)
+			}
+		}
+		system := []string{"system", resource}
+		if processorSummary, ok := data["ProcessorSummary"].(map[string]interface{}); ok {
+			if count, ok := processorSummary["Count"].(float64); ok {
+				add("devicemanager_processor_count", count, system...)
+			}
+			if count, ok := processorSummary["LogicalProcessorCount"].(float64); ok {
+				add("devicemanager_logical_processor_count", count, system...)
+			}
+		}
+		if memorySummary, ok := data["MemorySummary"].(map[string]interface{}); ok {
+			if memory, ok := memorySummary["TotalSystemMemoryGiB"].(float64); ok {
+				add("devicemanager_memory_gib", memory, system...)
+			}
+		}
+		if capacity, ok := data["CapacityBytes"].(float64); ok {
+			add("devicemanager_drive_capacity_bytes", capacity, "drive", resource)
+		}
+	}
+}
+
+//deviceTelemetry summarizes the cached CPU, memory and storage readings of a device
+type deviceTelemetry struct {
+	IPAddress             string
+	State                 string
+	PolledAt              time.Time
+	ProcessorCount        uint32
+	LogicalProcessorCount uint32
+	ProcessorHealth       string
+	MemoryGiB             float64
+	MemoryHealth          string
+	DriveCount            uint32
+	StorageBytes          uint64
+}
+
+/* getDeviceTelemetry() reads the CPU, memory and storage readings of the cached polled documents of a device,
+ * no request is sent to the device
+ */
+func getDeviceTelemetry(deviceIPAddress string, dev *device) (telemetry deviceTelemetry) {
+	telemetry.IPAddress = deviceIPAddress
+	telemetry.State = devicePollState(dev)
+	dev.PollStateLock.Lock()
+	telemetry.PolledAt = dev.LastPollOKAt
+	dev.PollStateLock.Unlock()
+	dev.PolledDataLock.Lock()
+	defer dev.PolledDataLock.Unlock()
+	for _, polled := range dev.PolledData {
+		data := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(polled), &data); err != nil {
+			continue
+		}
+		if processorSummary, ok := data["ProcessorSummary"].(map[string]interface{}); ok {
+			count, _ := processorSummary["Count"].(float64)
+			logicalCount, _ := processorSummary["LogicalProcessorCount"].(float64)
+			telemetry.ProcessorCount += uint32(count)
+			telemetry.LogicalProcessorCount += uint32(logicalCount)
+			processorStatus, _ := processorSummary["Status"].(map[string]interface{})
+			telemetry.ProcessorHealth, _ = processorStatus["HealthRollup"].(string)
+		}
+		if memorySummary, ok := data["MemorySummary"].(map[string]interface{}); ok {
+			memory, _ := memorySummary["TotalSystemMemoryGiB"].(float64)
+			telemetry.MemoryGiB += memory
+			memoryStatus, _ := memorySummary["Status"].(map[string]interface{})
+			telemetry.MemoryHealth, _ = memoryStatus["HealthRollup"].(string)
+		}
+		if capacity, ok := data["CapacityBytes"].(float64); ok {
+			telemetry.DriveCount++
+			telemetry.StorageBytes += uint64(capacity)
+		}
+	}
+	return telemetry
+}
+
+/* snapshotDevices() copies the attached devices under devicemapLock, so they can be read while devices are attached
+ * and detached, and returns their ip address:port sorted
+ */
+func (s *Server) snapshotDevices() (deviceIPAddresses []string, devices map[string]*device) {
+	s.devicemapLock.RLock()
+	defer s.devicemapLock.RUnlock()
+	devices = make(map[string]*device)
+	for deviceIPAddress, dev := range s.devicemap {
+		if dev != nil {
+			deviceIPAddresses = append(deviceIPAddresses, deviceIPAddress)
+			devices[deviceIPAddress] = dev
+		}
+	}
+	sort.Strings(deviceIPAddresses)
+	return deviceIPAddresses, devices
+}
+
+/* getFleetTelemetry() returns the cached telemetry of every attached device, sorted by ip address:port
+ */
+func (s *Server) getFleetTelemetry() (fleet []deviceTelemetry) {
+	deviceIPAddresses, devices := s.snapshotDevices()
+	for _, deviceIPAddress := range deviceIPAddresses {
+		fleet = append(fleet, getDeviceTelemetry(deviceIPAddress, devices[deviceIPAddress]))
+	}
+	return fleet
+}
+
+/* writeMetrics() writes the telemetry of all attached devices in the Prometheus text exposition format
+ */
+func (s *Server) writeMetrics(w io.Writer) {
+	samples := make(map[string][]string)
+	deviceIPAddresses, devices := s.snapshotDevices()
+	for _, deviceIPAddress := range deviceIPAddresses {
+		collectDeviceMetrics(deviceIPAddress, devices[deviceIPAddress], samples)
+	}
+	for _, family := range metricFamilies {
+		if len(samples[family[0]]) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", family[0], family[1], family[0])
+		for _, sample := range samples[family[0]] {
+			fmt.Fprintln(w, sample)
+		}
+	}
+}
+
+func (s *Server) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.writeMetrics(w)
+}
+
+func (s *Server) metricsMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.metricsHandler)
+	return mux
+}
+
+/* startMetricsServer() serves /metrics on GlobalConfig.MetricsAddress, which must not be one of the addresses the
+ * http and gRPC servers listen on
+ */
+func (s *Server) startMetricsServer() {
+	address := GlobalConfig.MetricsAddress
+	if address == GlobalConfig.Local || address == GlobalConfig.LocalGrpc {
+		logrus.Errorf("Metrics listen address %s is already used by the http or gRPC server", address)
+		return
+	}
+	logrus.Infof("starting metrics server on %s", address)
+	if err := http.ListenAndServe(address, s.metricsMux()); err != nil {
+		logrus.Errorf("Failed to run metrics server: %s ", err)
+	}
+}
@@ -0,0 +1,98 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	manager "devicemanager/proto"
+
+	"github.com/Shopify/sarama/mocks"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	s := &Server{devicemap: map[string]*device{"192.168.4.27:8888": {}}}
+	s.cachePolledData("192.168.4.27:8888", "/redfish/v1/Systems/1/ProcessorSummary/ProcessorMetrics",
+		`{"@odata.type": "#ProcessorMetrics.v1_4_0.ProcessorMetrics", "BandwidthPercent": 42.5}`)
+	s.cachePolledData("192.168.4.27:8888", "/redfish/v1/Systems/1/MemorySummary/MemoryMetrics",
+		`{"@odata.type": "#MemoryMetrics.v1_5_0.MemoryMetrics", "BandwidthPercent": 12, "CapacityUtilizationPercent": 63}`)
+	s.cachePolledData("192.168.4.27:8888", "/redfish/v1/Chassis/1/Thermal",
+		`{"Temperatures": [{"Name": "CPU1 Temp", "ReadingCelsius": 48}]}`)
+	var out bytes.Buffer
+	s.writeMetrics(&out)
+	for _, want := range []string{
+		`devicemanager_device_degraded{device="192.168.4.27:8888"} 0`,
+		`devicemanager_cpu_utilization_percent{device="192.168.4.27:8888",resource="/redfish/v1/Systems/1/ProcessorSummary/ProcessorMetrics"} 42.5`,
+		`devicemanager_memory_utilization_percent{device="192.168.4.27:8888",resource="/redfish/v1/Systems/1/MemorySummary/MemoryMetrics"} 63`,
+		`devicemanager_memory_bandwidth_percent{device="192.168.4.27:8888",resource="/redfish/v1/Systems/1/MemorySummary/MemoryMetrics"} 12`,
+		`devicemanager_temperature_celsius{device="192.168.4.27:8888",sensor="CPU1 Temp"} 48`,
+		"# TYPE devicemanager_cpu_utilization_percent gauge",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("writeMetrics() output is missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "devicemanager_power_consumed_watts") {
+		t.Errorf("writeMetrics() reported power without power samples:\n%s", out.String())
+	}
+}
+
+func TestMetricsOfAttachedDevice(t *testing.T) {
+	producer := mocks.NewAsyncProducer(t, nil)
+	defer producer.Close()
+	s := &Server{devicemap: make(map[string]*device), groups: make(map[string][]string), dataproducer: producer}
+	list := &manager.DeviceList{Device: []*manager.DeviceInfo{{IpAddress: "127.0.0.1:8888"}}}
+	if _, err := s.SendDeviceList(context.Background(), list); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		s.devicemap["127.0.0.1:8888"].Datacollector.quit <- true
+		<-s.devicemap["127.0.0.1:8888"].Datacollector.getdataend
+	}()
+	s.cachePolledData("127.0.0.1:8888", "/redfish/v1/Systems/1/ProcessorSummary/ProcessorMetrics",
+		`{"@odata.type": "#ProcessorMetrics.v1_4_0.ProcessorMetrics", "BandwidthPercent": 7}`)
+	server := httptest.NewServer(s.metricsMux())
+	defer server.Close()
+	response, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`devicemanager_device_degraded{device="127.0.0.1:8888"} 0`,
+		`devicemanager_cpu_utilization_percent{device="127.0.0.1:8888",resource="/redfish/v1/Systems/1/ProcessorSummary/ProcessorMetrics"} 7`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("/metrics is missing %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+//Redundancy states reported to the client
+const (
+	RedundancyOK   = "REDUNDANT"
+	RedundancyLost = "REDUNDANCY_LOST"
+)
+
+//redundancyGroup holds one entry of the Redundancy array of a chassis Thermal or Power resource
+type redundancyGroup struct {
+	OdataID         string
+	Name            string
+	Mode            string
+	MinNumNeeded    uint32
+	MaxNumSupported uint32
+	MemberCount     uint32
+	State           string
+	Health          string
+}
+
+/* getRedundancyStatus() returns REDUNDANCY_LOST when the redundancy group is not healthy, one more failure of a fan
+ * or a power supply may then stop the cooling or the power of the device
+ */
+func getRedundancyStatus(redundancy redundancyGroup) string {
+	if redundancy.Health != "" && redundancy.Health != "OK" {
+		return RedundancyLost
+	}
+	return RedundancyOK
+}
+
+/* parseRedundancyGroups() reads the Redundancy array of a chassis Thermal or Power resource
+ */
+func parseRedundancyGroups(resourceData map[string]interface{}) (redundancies []redundancyGroup) {
+	entries, _ := resourceData["Redundancy"].([]interface{})
+	for _, entry := range entries {
+		entryData, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		redundancy := redundancyGroup{}
+		redundancy.OdataID, _ = entryData["@odata.id"].(string)
+		redundancy.Name, _ = entryData["Name"].(string)
+		redundancy.Mode, _ = entryData["Mode"].(string)
+		minNumNeeded, _ := entryData["MinNumNeeded"].(float64)
+		maxNumSupported, _ := entryData["MaxNumSupported"].(float64)
+		redundancy.MinNumNeeded = uint32(minNumNeeded)
+		redundancy.MaxNumSupported = uint32(maxNumSupported)
+		members, _ := entryData["RedundancySet"].([]interface{})
+		redundancy.MemberCount = uint32(len(members))
+		entryStatus, _ := entryData["Status"].(map[string]interface{})
+		redundancy.State, _ = entryStatus["State"].(string)
+		redundancy.Health, _ = entryStatus["Health"].(string)
+		redundancies = append(redundancies, redundancy)
+	}
+	return redundancies
+}
+
+/* getThermalRedundancy() reads the fan/thermal Redundancy array of every chassis of device
+ */
+func (s *Server) getThermalRedundancy(deviceIPAddress, authStr string) (redundancies []redundancyGroup, statusCode int, err error) {
+	userAuthData := s.getUserAuthData(deviceIPAddress, authStr)
+	if (userAuthData == userAuth{}) {
+		logrus.Errorf(ErrUserAuthNotFound.String())
+		return nil, http.StatusBadRequest, errors.New(ErrUserAuthNotFound.String())
+	}
+	for _, chassisOdataID := range getMemberOdataIds(deviceIPAddress, RfChassis, userAuthData) {
+		thermalData, _, _ := getHTTPBodyDataByRfAPI(deviceIPAddress, chassisOdataID+"/Thermal", userAuthData)
+		redundancies = append(redundancies, parseRedundancyGroups(thermalData)...)
+	}
+	if len(redundancies) == 0 {
+		logrus.Errorf(ErrThermalRedundancyNotSupported.String())
+		return nil, http.StatusNotImplemented, errors.New(ErrThermalRedundancyNotSupported.String())
+	}
+	return redundancies, http.StatusOK, nil
+}
@@ -0,0 +1,62 @@
+/* Edgecore DeviceManager
+ * Copyright 2020-2021 Edgecore Networks, Inc.
+ *
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements. See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership. The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+//version, gitCommit and buildDate are set by the build, e.g. go build -ldflags "-X main.version=2.2.14 -X main.gitCommit=..."
+var (
+	version   = "dev"
+	gitCommit = "unknown"
+	buildDate = "unknown"
+)
+
+//redfishLibModule is the DMTF Redfish library the manager is built with
+const redfishLibModule = "github.com/ODIM-Project/ODIM/lib-dmtf"
+
+/* redfishLibVersion() returns the module version of the Redfish library recorded in the binary, followed by the
+ * local replacement when the library is built from the source tree
+ */
+func redfishLibVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != redfishLibModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Version + " => " + dep.Replace.Path
+		}
+		return dep.Version
+	}
+	return "unknown"
+}
+
+//goVersion returns the Go release the manager is built with
+func goVersion() string {
+	return runtime.Version()
+}